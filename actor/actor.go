@@ -0,0 +1,206 @@
+//
+//  actor.go
+//  actor
+//
+//  Created by d-exclaimation on 1:48 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package actor
+
+import (
+	"errors"
+	"fmt"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"sync"
+)
+
+var (
+	// ErrStopped is the error when sending to an actor that has stopped
+	ErrStopped = errors.New("actor: Actor has stopped")
+
+	// ErrMailboxFull is the error when sending to an actor with a full mailbox
+	ErrMailboxFull = errors.New("actor: Mailbox is full")
+
+	// ErrStashFull is the error when stashing more messages than the mailbox capacity
+	ErrStashFull = errors.New("actor: Stash is full")
+)
+
+// Behavior is the function that handle each message for an actor
+type Behavior[M any] func(ctx *Context[M], msg M)
+
+// Actor is a goroutine with a bounded mailbox that process typed messages one at a time.
+//
+//  counter := actor.Spawn[int](func(ctx *actor.Context[int], msg int) {
+//      total += msg
+//  })
+//  _ = counter.Tell(1)
+//
+// The behavior can be swapped using Context.Become and messages can be deferred using Context.Stash.
+type Actor[M any] struct {
+	// mailbox is the bounded channel of incoming messages
+	mailbox chan envelope[M]
+
+	// sealing guards sending into the mailbox against draining it on shutdown
+	sealing sync.Mutex
+
+	// sealed is the state to indicate whether the mailbox no longer accepts messages
+	sealed bool
+
+	// acid is the shutdown channel
+	acid chan Signal
+
+	// done is closed once the actor stopped
+	done chan Signal
+
+	// stopping guards closing the acid channel
+	stopping sync.Once
+
+	// failure is the reason the actor stopped if it failed
+	failure error
+
+	// preStart is the hook before processing messages
+	preStart func()
+
+	// postStop is the hook after the actor stopped
+	postStop func(error)
+}
+
+// Spawn instantiate a new actor and run the behavior in a separate goroutine.
+func Spawn[M any](behavior Behavior[M], opts ...Option) *Actor[M] {
+	size := 16
+	ac := &Actor[M]{
+		acid:     make(chan Signal),
+		done:     make(chan Signal),
+		preStart: func() {},
+		postStop: func(error) {},
+	}
+
+	// Setup for optional fields and configuration
+	for _, opt := range opts {
+		switch opt.(type) {
+		case mailboxSized:
+			size = int(opt.(mailboxSized))
+		case preStart:
+			ac.preStart = opt.(preStart)
+		case postStop:
+			ac.postStop = opt.(postStop)
+		}
+	}
+	if size < 1 {
+		size = 1
+	}
+	ac.mailbox = make(chan envelope[M], size)
+
+	go ac.receive(&Context[M]{
+		self:     ac,
+		behavior: behavior,
+		capacity: size,
+	})
+	return ac
+}
+
+// envelope is a message with the function to fail its reply if the actor abandons it
+type envelope[M any] struct {
+	msg     M
+	abandon func(error)
+}
+
+// receive is method for actor behavior for handling messages one at a time
+func (a *Actor[M]) receive(ctx *Context[M]) {
+	returned := false
+	defer func() {
+		if r := recover(); !returned {
+			a.failure = fmt.Errorf("actor: Behavior panicked with %v", r)
+			if ctx.current.abandon != nil {
+				ctx.current.abandon(a.failure)
+			}
+		}
+		a.Stop()
+		a.abandon(ctx)
+		a.postStop(a.failure)
+		close(a.done)
+	}()
+
+	a.preStart()
+	for !ctx.stopped {
+		env, ok := ctx.next()
+		if !ok {
+			break
+		}
+		ctx.current = env
+		ctx.behavior(ctx, env.msg)
+	}
+	returned = true
+}
+
+// abandon seals the mailbox and fails the replies of every message left unprocessed with ErrStopped
+func (a *Actor[M]) abandon(ctx *Context[M]) {
+	a.sealing.Lock()
+	a.sealed = true
+	a.sealing.Unlock()
+
+	left := append(ctx.unstashed, ctx.stash...)
+	for drained := false; !drained; {
+		select {
+		case env := <-a.mailbox:
+			left = append(left, env)
+		default:
+			drained = true
+		}
+	}
+	for _, env := range left {
+		if env.abandon != nil {
+			env.abandon(ErrStopped)
+		}
+	}
+	ctx.unstashed, ctx.stash = nil, nil
+}
+
+// Tell sends a message to the actor without waiting, and return an error if stopped or the mailbox is full
+func (a *Actor[M]) Tell(msg M) error {
+	return a.send(envelope[M]{msg: msg})
+}
+
+// send puts the envelope into the mailbox unless the actor stopped or the mailbox is full
+func (a *Actor[M]) send(env envelope[M]) error {
+	a.sealing.Lock()
+	defer a.sealing.Unlock()
+	if a.sealed {
+		return ErrStopped
+	}
+	select {
+	case <-a.acid:
+		return ErrStopped
+	default:
+	}
+
+	select {
+	case a.mailbox <- env:
+		return nil
+	default:
+		return ErrMailboxFull
+	}
+}
+
+// Stop shutdown the actor after the current message, where replies of messages left in the mailbox fail with ErrStopped
+func (a *Actor[M]) Stop() {
+	a.stopping.Do(func() {
+		close(a.acid)
+	})
+}
+
+// Done returns a channel that is closed once the actor stopped and the PostStop hook finished
+func (a *Actor[M]) Done() <-chan Signal {
+	return a.done
+}
+
+// Err return the reason the actor stopped, nil if stopped normally or still running
+func (a *Actor[M]) Err() error {
+	select {
+	case <-a.done:
+		return a.failure
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package actor_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/actor"
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+type request struct {
+	reply *task.Promise[int]
+	stash bool
+	boom  bool
+}
+
+// settles fails the test if the Task did not settle in time
+func settles[T any](t *testing.T, tk *task.Task[T]) error {
+	t.Helper()
+	select {
+	case <-tk.Done():
+	case <-time.After(time.Second):
+		t.Fatal("reply was never settled")
+	}
+	_, err := tk.Await()
+	return err
+}
+
+func TestAskReply(t *testing.T) {
+	ac := actor.Spawn[request](func(ctx *actor.Context[request], msg request) {
+		_ = msg.reply.Success(1)
+	})
+	defer ac.Stop()
+
+	value, err := actor.Ask[request, int](ac, func(reply *task.Promise[int]) request {
+		return request{reply: reply}
+	}).Await()
+	if value != 1 || err != nil {
+		t.Fatalf("expected 1, got %v %v", value, err)
+	}
+}
+
+func TestAskStoppedWithMailbox(t *testing.T) {
+	gate := make(chan struct{})
+	ac := actor.Spawn[request](func(ctx *actor.Context[request], msg request) {
+		<-gate
+		_ = msg.reply.Success(1)
+		ctx.Stop()
+	})
+
+	first := actor.Ask[request, int](ac, func(reply *task.Promise[int]) request {
+		return request{reply: reply}
+	})
+	second := actor.Ask[request, int](ac, func(reply *task.Promise[int]) request {
+		return request{reply: reply}
+	})
+	close(gate)
+
+	if err := settles(t, second); !errors.Is(err, actor.ErrStopped) {
+		t.Fatalf("expected ErrStopped, got %v", err)
+	}
+	if err := settles(t, first); err != nil {
+		t.Fatalf("expected the processed message to be replied, got %v", err)
+	}
+}
+
+func TestAskStoppedWithStash(t *testing.T) {
+	ac := actor.Spawn[request](func(ctx *actor.Context[request], msg request) {
+		if msg.stash {
+			_ = ctx.Stash()
+			return
+		}
+		ctx.Stop()
+	})
+
+	stashed := actor.Ask[request, int](ac, func(reply *task.Promise[int]) request {
+		return request{reply: reply, stash: true}
+	})
+	_ = ac.Tell(request{})
+
+	if err := settles(t, stashed); !errors.Is(err, actor.ErrStopped) {
+		t.Fatalf("expected ErrStopped, got %v", err)
+	}
+}
+
+func TestAskPanic(t *testing.T) {
+	ac := actor.Spawn[request](func(ctx *actor.Context[request], msg request) {
+		if msg.boom {
+			panic("boom")
+		}
+	})
+
+	tk := actor.Ask[request, int](ac, func(reply *task.Promise[int]) request {
+		return request{reply: reply, boom: true}
+	})
+	if err := settles(t, tk); err == nil || errors.Is(err, actor.ErrStopped) {
+		t.Fatalf("expected the panic, got %v", err)
+	}
+	<-ac.Done()
+	if ac.Err() == nil {
+		t.Fatal("expected the actor to fail")
+	}
+}
+
+func TestAskRacingStop(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		ac := actor.Spawn[request](func(ctx *actor.Context[request], msg request) {
+			_ = msg.reply.Success(1)
+		})
+		go ac.Stop()
+		tk := actor.Ask[request, int](ac, func(reply *task.Promise[int]) request {
+			return request{reply: reply}
+		})
+		if err := settles(t, tk); err != nil && !errors.Is(err, actor.ErrStopped) {
+			t.Fatalf("expected a reply or ErrStopped, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+//
+//  ask.go
+//  actor
+//
+//  Created by d-exclaimation on 2:31 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package actor
+
+import "github.com/d-exclaimation/gocurrent/task"
+
+// Ask sends a message built with a reply Promise to the actor and return the Task of the reply.
+//
+// The reply fails with ErrStopped if the actor stopped before processing the message,
+// or with the panic if the behavior panicked while processing it.
+//
+//  t := actor.Ask[Message, int](ac, func(reply *task.Promise[int]) Message {
+//      return Message{Reply: reply}
+//  })
+func Ask[M, R any](ac *Actor[M], build func(reply *task.Promise[R]) M) *task.Task[R] {
+	reply := task.Maybe[R]()
+	env := envelope[M]{
+		msg: build(reply),
+		abandon: func(err error) {
+			_ = reply.Failure(err)
+		},
+	}
+	if err := ac.send(env); err != nil {
+		_ = reply.Failure(err)
+	}
+	return reply.Task()
+}
@@ -0,0 +1,80 @@
+//
+//  context.go
+//  actor
+//
+//  Created by d-exclaimation on 2:02 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package actor
+
+// Context is the state of an actor only accessible within its behavior
+type Context[M any] struct {
+	// self is the actor owning this context
+	self *Actor[M]
+
+	// behavior is the current message handler
+	behavior Behavior[M]
+
+	// current is the message being processed
+	current envelope[M]
+
+	// stash is the deferred messages
+	stash []envelope[M]
+
+	// unstashed is the messages to be processed before the mailbox
+	unstashed []envelope[M]
+
+	// capacity is the maximum stash size
+	capacity int
+
+	// stopped is the state to indicate whether the actor should stop
+	stopped bool
+}
+
+// next takes the next message from unstashed messages first then the mailbox
+func (c *Context[M]) next() (envelope[M], bool) {
+	if len(c.unstashed) > 0 {
+		env := c.unstashed[0]
+		c.unstashed = c.unstashed[1:]
+		return env, true
+	}
+
+	select {
+	case <-c.self.acid:
+		return envelope[M]{}, false
+	case env := <-c.self.mailbox:
+		return env, true
+	}
+}
+
+// Self return the actor owning this context
+func (c *Context[M]) Self() *Actor[M] {
+	return c.self
+}
+
+// Become swaps the behavior for all the following messages
+func (c *Context[M]) Become(behavior Behavior[M]) {
+	c.behavior = behavior
+}
+
+// Stash defers the current message until UnstashAll is called
+func (c *Context[M]) Stash() error {
+	if len(c.stash) >= c.capacity {
+		return ErrStashFull
+	}
+	c.stash = append(c.stash, c.current)
+	return nil
+}
+
+// UnstashAll puts back all the stashed messages in order before any message in the mailbox
+func (c *Context[M]) UnstashAll() {
+	c.unstashed = append(c.stash, c.unstashed...)
+	c.stash = nil
+}
+
+// Stop shutdown the actor after the current message
+func (c *Context[M]) Stop() {
+	c.stopped = true
+	c.self.Stop()
+}
@@ -0,0 +1,46 @@
+//
+//  option.go
+//  actor
+//
+//  Created by d-exclaimation on 2:14 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package actor
+
+// Option is a interface pattern to be used for spawning actors
+type Option interface {
+	// implement is a required method for allowing any settings to follow Option
+	implement()
+}
+
+// mailboxSized is an Option for actor with specified mailbox capacity
+type mailboxSized int
+
+func (m mailboxSized) implement() {}
+
+// WithMailbox is an Option to set the capacity of the actor's mailbox (and stash)
+func WithMailbox(size int) Option {
+	return mailboxSized(size)
+}
+
+// preStart is an Option for actor with a hook before processing any message
+type preStart func()
+
+func (p preStart) implement() {}
+
+// WithPreStart is an Option to add a hook called in the actor's goroutine before processing any message
+func WithPreStart(hook func()) Option {
+	return preStart(hook)
+}
+
+// postStop is an Option for actor with a hook after it stopped
+type postStop func(error)
+
+func (p postStop) implement() {}
+
+// WithPostStop is an Option to add a hook called in the actor's goroutine after it stopped,
+// with the failure that stopped it if any
+func WithPostStop(hook func(error)) Option {
+	return postStop(hook)
+}
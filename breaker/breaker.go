@@ -0,0 +1,314 @@
+//
+//  breaker.go
+//  breaker
+//
+//  Created by d-exclaimation on 4:58 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package breaker
+
+import (
+	"errors"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/task"
+	"github.com/d-exclaimation/gocurrent/try"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrOpen is the error when a call is rejected because the Breaker is open
+	ErrOpen = errors.New("breaker: Breaker is open")
+
+	// errNilTask is the error when the function given to Do returned no Task
+	errNilTask = errors.New("breaker: Function returned a nil Task")
+)
+
+// State is the state of a Breaker
+type State int
+
+const (
+	// Closed is when calls are allowed and failures are counted
+	Closed State = iota
+
+	// Open is when calls are rejected until the cool-down passed
+	Open
+
+	// HalfOpen is when a limited number of trial calls decide whether to close or open again
+	HalfOpen
+)
+
+// String return the name of the state
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Transition is a change of state of a Breaker
+type Transition struct {
+	// From is the previous state
+	From State
+
+	// To is the new state
+	To State
+
+	// Err is the failure that opened the Breaker if any
+	Err error
+
+	// Time is when the transition happened
+	Time time.Time
+}
+
+// Breaker is a circuit breaker that stops calling a failing dependency until it had time to recover.
+//
+//  b := breaker.New(breaker.Policy{ConsecutiveFailures: 5, CoolDown: 30 * time.Second})
+//  b.Events().On(func(ev Any) {
+//      log.Println(ev.(breaker.Transition).To)
+//  })
+//  res, err := breaker.Do(b, fetchUser).Await()
+type Breaker struct {
+	// policy is when the Breaker opens and how it recovers
+	policy Policy
+
+	// mutex guards the state and counters
+	mutex sync.Mutex
+
+	// state is the current state
+	state State
+
+	// generation is incremented on every transition, so results of calls from a previous state are ignored
+	generation int
+
+	// consecutive is the number of failures in a row while closed
+	consecutive int
+
+	// calls is the number of calls within the window while closed
+	calls int
+
+	// failures is the number of failures within the window while closed
+	failures int
+
+	// windowStart is the time the window started
+	windowStart time.Time
+
+	// openedAt is the time the Breaker opened
+	openedAt time.Time
+
+	// trials is the number of trial calls admitted while half-open
+	trials int
+
+	// successes is the number of trial calls succeeded while half-open
+	successes int
+
+	// clock is the source of time captured from clock.Default
+	clock clock.Clock
+
+	// events is the Jet for state transitions
+	events *jet.Jet
+
+	// pending are the transitions not yet published, in order
+	pending []Transition
+
+	// wake signals the publisher that there are pending transitions
+	wake chan Signal
+
+	// stop is closed once the Breaker is closed with Close
+	stop chan Signal
+
+	// stopping guards closing the stop channel
+	stopping sync.Once
+}
+
+// New instantiate a new closed Breaker with the policy.
+//
+// The Breaker publishes its Events from a goroutine that only exits once the Breaker is closed with Close.
+func New(policy Policy) *Breaker {
+	if policy.HalfOpenRequests < 1 {
+		policy.HalfOpenRequests = 1
+	}
+	c := clock.Default()
+	b := &Breaker{
+		policy:      policy,
+		state:       Closed,
+		windowStart: c.Now(),
+		clock:       c,
+		events:      jet.New(),
+		wake:        make(chan Signal, 1),
+		stop:        make(chan Signal),
+	}
+	go b.publish()
+	return b
+}
+
+// Do runs the Task from the function if the Breaker allows it and records its result,
+// otherwise return a Task that already failed with ErrOpen without calling the function.
+//
+// A function that panicked or returned a nil Task is recorded as a failure, and the returned Task fails with it.
+func Do[T any](b *Breaker, op func() *task.Task[T]) *task.Task[T] {
+	generation, err := b.acquire()
+	if err != nil {
+		p := task.Maybe[T]()
+		_ = p.Failure(err)
+		return p.Task()
+	}
+
+	inner, err := try.Catch[*task.Task[T]](func() (*task.Task[T], error) {
+		if inner := op(); inner != nil {
+			return inner, nil
+		}
+		return nil, errNilTask
+	}).ToOption()
+	if err != nil {
+		b.record(generation, err)
+		p := task.Maybe[T]()
+		_ = p.Failure(err)
+		return p.Task()
+	}
+
+	t := task.New[T](func() (T, error) {
+		res, err := inner.Await()
+		b.record(generation, err)
+		return res, err
+	}).Named("Breaker")
+	t.Run()
+	return t
+}
+
+// State return the current state, moving to half-open if the cool-down passed
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.cool()
+	return b.state
+}
+
+// Events return the Jet of Transition, which closes once the Breaker is closed with Close
+//
+// Transitions are published in order from a separate goroutine, so a slow consumer never blocks any call.
+func (b *Breaker) Events() *jet.Jet {
+	return b.events
+}
+
+// Close shutdown the Jet of Transition and its publisher, the Breaker itself keeps working
+func (b *Breaker) Close() {
+	b.stopping.Do(func() {
+		close(b.stop)
+		b.events.Close()
+	})
+}
+
+// publish pushes pending transitions into the Jet in order until the Breaker is closed
+func (b *Breaker) publish() {
+	for {
+		select {
+		case <-b.wake:
+		case <-b.stop:
+			return
+		}
+
+		b.mutex.Lock()
+		batch := b.pending
+		b.pending = nil
+		b.mutex.Unlock()
+
+		for _, transition := range batch {
+			b.events.Up(transition)
+		}
+	}
+}
+
+// acquire admits a call and return the generation it was admitted in, or ErrOpen if rejected
+func (b *Breaker) acquire() (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.cool()
+
+	switch b.state {
+	case Open:
+		return 0, ErrOpen
+	case HalfOpen:
+		if b.trials >= b.policy.HalfOpenRequests {
+			return 0, ErrOpen
+		}
+		b.trials++
+	}
+	return b.generation, nil
+}
+
+// record counts the result of a call admitted in the generation
+func (b *Breaker) record(generation int, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if generation != b.generation {
+		return
+	}
+	failed := b.policy.failed(err)
+
+	switch b.state {
+	case Closed:
+		now := b.clock.Now()
+		if b.policy.Window > 0 && now.Sub(b.windowStart) >= b.policy.Window {
+			b.calls, b.failures, b.windowStart = 0, 0, now
+		}
+		b.calls++
+		if !failed {
+			b.consecutive = 0
+			return
+		}
+		b.failures++
+		b.consecutive++
+		if b.policy.tripped(b.consecutive, b.calls, b.failures) {
+			b.transition(Open, err)
+		}
+	case HalfOpen:
+		if failed {
+			b.transition(Open, err)
+			return
+		}
+		b.successes++
+		if b.successes >= b.policy.HalfOpenRequests {
+			b.transition(Closed, nil)
+		}
+	}
+}
+
+// cool moves to half-open once the cool-down passed (must hold the mutex)
+func (b *Breaker) cool() {
+	if b.state == Open && !b.clock.Now().Before(b.openedAt.Add(b.policy.CoolDown)) {
+		b.transition(HalfOpen, nil)
+	}
+}
+
+// transition changes the state, resets the counters, and queue the Transition to be published (must hold the mutex)
+func (b *Breaker) transition(to State, err error) {
+	now := b.clock.Now()
+	from := b.state
+	b.state = to
+	b.generation++
+	b.consecutive, b.calls, b.failures, b.windowStart = 0, 0, 0, now
+	b.trials, b.successes = 0, 0
+	if to == Open {
+		b.openedAt = now
+	}
+	b.pending = append(b.pending, Transition{
+		From: from,
+		To:   to,
+		Err:  err,
+		Time: now,
+	})
+	select {
+	case b.wake <- Signal{}:
+	default:
+	}
+}
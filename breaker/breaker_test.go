@@ -0,0 +1,230 @@
+package breaker_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/breaker"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+	"github.com/d-exclaimation/gocurrent/task"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+var errDown = errors.New("down")
+
+func fail() *task.Task[int] {
+	return task.Async(func() (int, error) {
+		return 0, errDown
+	})
+}
+
+func succeed() *task.Task[int] {
+	return task.Async(func() (int, error) {
+		return 1, nil
+	})
+}
+
+// call runs the function through the Breaker and return its error
+func call(b *breaker.Breaker, op func() *task.Task[int]) error {
+	_, err := breaker.Do(b, op).Await()
+	return err
+}
+
+func TestConsecutiveFailures(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	b := breaker.New(breaker.Policy{ConsecutiveFailures: 2, CoolDown: time.Second})
+	defer b.Close()
+
+	_ = call(b, fail)
+	_ = call(b, succeed)
+	_ = call(b, fail)
+	if b.State() != breaker.Closed {
+		t.Fatalf("expected a success to reset the count, got %v", b.State())
+	}
+	_ = call(b, fail)
+	if b.State() != breaker.Open {
+		t.Fatalf("expected open, got %v", b.State())
+	}
+
+	called := false
+	err := call(b, func() *task.Task[int] {
+		called = true
+		return succeed()
+	})
+	if !errors.Is(err, breaker.ErrOpen) || called {
+		t.Fatalf("expected to fail fast with ErrOpen, got %v", err)
+	}
+}
+
+func TestFailureRatio(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	b := breaker.New(breaker.Policy{FailureRatio: 0.75, MinRequests: 4, Window: time.Minute, CoolDown: time.Second})
+	defer b.Close()
+
+	_ = call(b, fail)
+	_ = call(b, succeed)
+	_ = call(b, fail)
+	if b.State() != breaker.Closed {
+		t.Fatalf("expected closed below the minimum requests, got %v", b.State())
+	}
+	_ = call(b, fail)
+	if b.State() != breaker.Open {
+		t.Fatalf("expected open at the failure ratio, got %v", b.State())
+	}
+}
+
+func TestFailureRatioWindow(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	b := breaker.New(breaker.Policy{FailureRatio: 0.5, MinRequests: 2, Window: time.Minute})
+	defer b.Close()
+
+	_ = call(b, fail)
+	vc.Advance(time.Minute)
+	_ = call(b, succeed)
+	_ = call(b, succeed)
+	if b.State() != breaker.Closed {
+		t.Fatalf("expected failures of a previous window to be forgotten, got %v", b.State())
+	}
+}
+
+func TestHalfOpen(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	b := breaker.New(breaker.Policy{ConsecutiveFailures: 1, CoolDown: time.Second, HalfOpenRequests: 2})
+	defer b.Close()
+
+	_ = call(b, fail)
+	vc.Advance(time.Second)
+	if b.State() != breaker.HalfOpen {
+		t.Fatalf("expected half-open after the cool-down, got %v", b.State())
+	}
+	_ = call(b, fail)
+	if b.State() != breaker.Open {
+		t.Fatalf("expected a failed trial to open again, got %v", b.State())
+	}
+
+	vc.Advance(time.Second)
+	_ = call(b, succeed)
+	if b.State() != breaker.HalfOpen {
+		t.Fatalf("expected half-open until every trial succeeded, got %v", b.State())
+	}
+	_ = call(b, succeed)
+	if b.State() != breaker.Closed {
+		t.Fatalf("expected closed, got %v", b.State())
+	}
+}
+
+func TestHalfOpenLimitsTrials(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	b := breaker.New(breaker.Policy{ConsecutiveFailures: 1, CoolDown: time.Second})
+	defer b.Close()
+
+	_ = call(b, fail)
+	vc.Advance(time.Second)
+
+	gate := make(chan struct{})
+	trial := breaker.Do(b, func() *task.Task[int] {
+		return task.Async(func() (int, error) {
+			<-gate
+			return 1, nil
+		})
+	})
+	if err := call(b, succeed); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("expected a second trial to be rejected, got %v", err)
+	}
+	close(gate)
+	if _, err := trial.Await(); err != nil {
+		t.Fatal(err)
+	}
+	if b.State() != breaker.Closed {
+		t.Fatalf("expected closed, got %v", b.State())
+	}
+}
+
+func TestHalfOpenBrokenTrial(t *testing.T) {
+	trials := map[string]func() *task.Task[int]{
+		"panic": func() *task.Task[int] {
+			panic("boom")
+		},
+		"nil": func() *task.Task[int] {
+			return nil
+		},
+	}
+	for name, trial := range trials {
+		t.Run(name, func(t *testing.T) {
+			vc := clocktest.NewVirtual(time.Unix(0, 0))
+			defer clock.SetDefault(vc)()
+			b := breaker.New(breaker.Policy{ConsecutiveFailures: 1, CoolDown: time.Second})
+			defer b.Close()
+
+			_ = call(b, fail)
+			vc.Advance(time.Second)
+			if err := call(b, trial); err == nil || errors.Is(err, breaker.ErrOpen) {
+				t.Fatalf("expected the trial to fail with its own error, got %v", err)
+			}
+			if b.State() != breaker.Open {
+				t.Fatalf("expected the broken trial to open again, got %v", b.State())
+			}
+
+			// The trial slot is not held forever, so the Breaker recovers after the next cool-down
+			vc.Advance(time.Second)
+			if err := call(b, succeed); err != nil {
+				t.Fatalf("expected the next trial to be admitted, got %v", err)
+			}
+			if b.State() != breaker.Closed {
+				t.Fatalf("expected closed, got %v", b.State())
+			}
+		})
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	b := breaker.New(breaker.Policy{ConsecutiveFailures: 1, CoolDown: time.Second})
+
+	sink := b.Events().Sink()
+	_ = call(b, fail)
+	vc.Advance(time.Second)
+	_ = call(b, succeed)
+
+	expected := []breaker.State{breaker.Open, breaker.HalfOpen, breaker.Closed}
+	for _, to := range expected {
+		transition := (<-sink).(breaker.Transition)
+		if transition.To != to {
+			t.Fatalf("expected transition to %v, got %v", to, transition.To)
+		}
+	}
+	b.Close()
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	b := breaker.New(breaker.Policy{ConsecutiveFailures: 1})
+	defer b.Close()
+
+	// A subscriber that never reads
+	_ = b.Events().Sink()
+
+	done := make(chan Signal)
+	go func() {
+		for i := 0; i < 100; i++ {
+			_ = call(b, fail)
+			_ = call(b, succeed)
+			_ = call(b, succeed)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("calls were blocked by the subscriber")
+	}
+}
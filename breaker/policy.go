@@ -0,0 +1,56 @@
+//
+//  policy.go
+//  breaker
+//
+//  Created by d-exclaimation on 5:12 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package breaker
+
+import "time"
+
+// Policy is when a Breaker opens and how it recovers.
+//
+// A Breaker with neither threshold set never opens.
+type Policy struct {
+	// ConsecutiveFailures opens the Breaker after this many failures in a row, disabled if zero
+	ConsecutiveFailures int
+
+	// FailureRatio opens the Breaker once the ratio of failures within the Window reach it, disabled if zero
+	FailureRatio float64
+
+	// MinRequests is the number of calls within the Window before FailureRatio applies
+	MinRequests int
+
+	// Window is the period the failure ratio is counted over, counted since the Breaker closed if zero
+	Window time.Duration
+
+	// CoolDown is how long the Breaker stays open before allowing trial calls
+	CoolDown time.Duration
+
+	// HalfOpenRequests is the number of trial calls that must succeed to close the Breaker, 1 if zero
+	HalfOpenRequests int
+
+	// IsFailure decides whether an error counts as a failure, all errors if nil
+	IsFailure func(error) bool
+}
+
+// tripped indicates whether the counts in the closed state should open the Breaker
+func (p Policy) tripped(consecutive, calls, failures int) bool {
+	if p.ConsecutiveFailures > 0 && consecutive >= p.ConsecutiveFailures {
+		return true
+	}
+	if p.FailureRatio <= 0 || calls == 0 || calls < p.MinRequests {
+		return false
+	}
+	return float64(failures)/float64(calls) >= p.FailureRatio
+}
+
+// failed indicates whether the error counts as a failure
+func (p Policy) failed(err error) bool {
+	if err == nil {
+		return false
+	}
+	return p.IsFailure == nil || p.IsFailure(err)
+}
@@ -0,0 +1,189 @@
+//
+//  bulkhead.go
+//  bulkhead
+//
+//  Created by d-exclaimation on 6:51 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package bulkhead
+
+import (
+	"container/list"
+	"errors"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrFull is the error when a caller is rejected because the queue is full
+	ErrFull = errors.New("bulkhead: Queue is full")
+
+	// ErrTimeout is the error when a caller is rejected after waiting too long
+	ErrTimeout = errors.New("bulkhead: Timed out waiting to enter")
+)
+
+// Bulkhead caps the number of concurrent callers for a dependency, with a limited queue of waiting callers.
+//
+//  db := bulkhead.New(8, bulkhead.WithQueue(32), bulkhead.WithWaitTimeout(time.Second))
+//  user := task.WithBulkhead(db, func() (User, error) {
+//      return queryUser(id)
+//  })
+//
+// Waiting callers are kept as callbacks rather than goroutines, so a full Bulkhead costs no goroutine per caller.
+type Bulkhead struct {
+	// mutex guards the running count, queue, and reaper
+	mutex sync.Mutex
+
+	// concurrency is the maximum number of admitted callers
+	concurrency int
+
+	// running is the number of admitted callers
+	running int
+
+	// queue are the waiting callers in order
+	queue list.List
+
+	// limit is the maximum number of waiting callers
+	limit int
+
+	// timeout is how long a caller waits, unlimited if zero
+	timeout time.Duration
+
+	// reaping is the state to indicate whether the goroutine expiring waiting callers is running
+	reaping bool
+
+	// clock is the source of time captured from clock.Default
+	clock clock.Clock
+}
+
+// waiter is a waiting caller
+type waiter struct {
+	admitted func(leave func(), err error)
+	deadline time.Time
+}
+
+// New instantiate a new Bulkhead allowing the number of concurrent callers,
+// with a queue as large as the concurrency by default
+func New(concurrency int, opts ...Option) *Bulkhead {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	b := &Bulkhead{
+		concurrency: concurrency,
+		limit:       concurrency,
+		clock:       clock.Default(),
+	}
+
+	// Setup for optional fields and configuration
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case queued:
+			b.limit = int(o)
+		case waitTimeout:
+			b.timeout = time.Duration(o)
+		}
+	}
+	if b.limit < 0 {
+		b.limit = 0
+	}
+	return b
+}
+
+// Enter calls the function with the function to leave once admitted, or with ErrFull or ErrTimeout if rejected.
+//
+// The function is called right away if admitted or rejected immediately, otherwise later by the caller that left.
+func (b *Bulkhead) Enter(admitted func(leave func(), err error)) {
+	b.mutex.Lock()
+	if b.running < b.concurrency && b.queue.Len() == 0 {
+		b.running++
+		b.mutex.Unlock()
+		admitted(b.leave(), nil)
+		return
+	}
+	if b.queue.Len() >= b.limit {
+		b.mutex.Unlock()
+		admitted(nil, ErrFull)
+		return
+	}
+
+	w := &waiter{admitted: admitted}
+	if b.timeout > 0 {
+		w.deadline = b.clock.Now().Add(b.timeout)
+		if !b.reaping {
+			b.reaping = true
+			go b.reap()
+		}
+	}
+	b.queue.PushBack(w)
+	b.mutex.Unlock()
+}
+
+// leave return the function that frees the slot once and admits the next waiting caller
+func (b *Bulkhead) leave() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			b.running--
+			next := b.queue.Front()
+			if next == nil {
+				b.mutex.Unlock()
+				return
+			}
+			b.queue.Remove(next)
+			b.running++
+			b.mutex.Unlock()
+			next.Value.(*waiter).admitted(b.leave(), nil)
+		})
+	}
+}
+
+// reap rejects waiting callers past their deadline with ErrTimeout until none are waiting
+func (b *Bulkhead) reap() {
+	for {
+		b.mutex.Lock()
+		front := b.queue.Front()
+		if front == nil {
+			b.reaping = false
+			b.mutex.Unlock()
+			return
+		}
+
+		// Waiters share the same timeout, so their deadlines are in queue order
+		now := b.clock.Now()
+		var expired []*waiter
+		for front != nil && !now.Before(front.Value.(*waiter).deadline) {
+			next := front.Next()
+			expired = append(expired, b.queue.Remove(front).(*waiter))
+			front = next
+		}
+		var wait time.Duration
+		if front != nil {
+			wait = front.Value.(*waiter).deadline.Sub(now)
+		}
+		b.mutex.Unlock()
+
+		for _, w := range expired {
+			w.admitted(nil, ErrTimeout)
+		}
+		if front != nil {
+			<-b.clock.After(wait)
+		}
+	}
+}
+
+// Running return the number of admitted callers
+func (b *Bulkhead) Running() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.running
+}
+
+// Queued return the number of waiting callers
+func (b *Bulkhead) Queued() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.queue.Len()
+}
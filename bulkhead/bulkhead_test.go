@@ -0,0 +1,147 @@
+package bulkhead_test
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/bulkhead"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+var _ task.Bulkhead = (*bulkhead.Bulkhead)(nil)
+
+func TestConcurrencyCap(t *testing.T) {
+	b := bulkhead.New(2, bulkhead.WithQueue(8))
+	var running, peak int32
+	tasks := make([]*task.Task[int], 0, 10)
+	for i := 0; i < 10; i++ {
+		tasks = append(tasks, task.WithBulkhead(b, func() (int, error) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if current <= p || atomic.CompareAndSwapInt32(&peak, p, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return 1, nil
+		}))
+	}
+	for _, tk := range tasks {
+		if _, err := tk.Await(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if peak > 2 {
+		t.Fatalf("expected at most 2 running, got %d", peak)
+	}
+}
+
+func TestQueueFull(t *testing.T) {
+	b := bulkhead.New(1, bulkhead.WithQueue(1))
+	gate := make(chan struct{})
+	defer close(gate)
+	block := func() (int, error) {
+		<-gate
+		return 1, nil
+	}
+
+	_ = task.WithBulkhead(b, block)
+	_ = task.WithBulkhead(b, block)
+	rejected := task.WithBulkhead(b, block)
+
+	// Rejected synchronously without a goroutine
+	res, ok := rejected.Poll()
+	if !ok || !errors.Is(res.Err(), bulkhead.ErrFull) {
+		t.Fatalf("expected ErrFull right away, got %v", res)
+	}
+}
+
+func TestDefaultQueueBounded(t *testing.T) {
+	b := bulkhead.New(2)
+	gate := make(chan struct{})
+	defer close(gate)
+
+	var rejected int
+	for i := 0; i < 10; i++ {
+		tk := task.WithBulkhead(b, func() (int, error) {
+			<-gate
+			return 1, nil
+		})
+		if res, ok := tk.Poll(); ok && errors.Is(res.Err(), bulkhead.ErrFull) {
+			rejected++
+		}
+	}
+	if rejected != 6 || b.Queued() != 2 {
+		t.Fatalf("expected a queue as large as the concurrency, got %d rejected and %d queued", rejected, b.Queued())
+	}
+}
+
+func TestQueuedHoldNoGoroutine(t *testing.T) {
+	b := bulkhead.New(1, bulkhead.WithQueue(1000))
+	gate := make(chan struct{})
+	_ = task.WithBulkhead(b, func() (int, error) {
+		<-gate
+		return 1, nil
+	})
+
+	before := runtime.NumGoroutine()
+	tasks := make([]*task.Task[int], 0, 1000)
+	for i := 0; i < 1000; i++ {
+		tasks = append(tasks, task.WithBulkhead(b, func() (int, error) {
+			return 1, nil
+		}))
+	}
+	if after := runtime.NumGoroutine(); after-before > 10 {
+		t.Fatalf("expected queued callers to hold no goroutine, got %d more", after-before)
+	}
+	close(gate)
+	for _, tk := range tasks {
+		if _, err := tk.Await(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	b := bulkhead.New(1, bulkhead.WithWaitTimeout(time.Second))
+	gate := make(chan struct{})
+	defer close(gate)
+
+	_ = task.WithBulkhead(b, func() (int, error) {
+		<-gate
+		return 1, nil
+	})
+	waiting := task.WithBulkhead(b, func() (int, error) {
+		return 1, nil
+	})
+	vc.BlockUntil(1)
+	vc.Advance(time.Second)
+	if _, err := waiting.Await(); !errors.Is(err, bulkhead.ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if b.Queued() != 0 {
+		t.Fatalf("expected the timed out caller to leave the queue, got %d", b.Queued())
+	}
+}
+
+func TestPanicLeaves(t *testing.T) {
+	b := bulkhead.New(1)
+	_, err := task.WithBulkhead(b, func() (int, error) {
+		panic("boom")
+	}).Await()
+	if err == nil {
+		t.Fatal("expected the panic as failure")
+	}
+	if _, err := task.WithBulkhead(b, func() (int, error) { return 1, nil }).Await(); err != nil {
+		t.Fatalf("expected the slot to be freed, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+//
+//  option.go
+//  bulkhead
+//
+//  Created by d-exclaimation on 7:04 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package bulkhead
+
+import "time"
+
+// Option is a interface pattern to be used for constructing bulkheads
+type Option interface {
+	// implement is a required method for allowing any settings to follow Option
+	implement()
+}
+
+// queued is an Option for bulkhead with a limit of waiting callers
+type queued int
+
+func (q queued) implement() {}
+
+// WithQueue is an Option to reject callers once this many are waiting, where zero rejects any caller that cannot
+// enter immediately (as large as the concurrency by default)
+func WithQueue(limit int) Option {
+	return queued(limit)
+}
+
+// waitTimeout is an Option for bulkhead with a limit on how long a caller waits
+type waitTimeout time.Duration
+
+func (w waitTimeout) implement() {}
+
+// WithWaitTimeout is an Option to reject callers that waited longer than the duration
+func WithWaitTimeout(timeout time.Duration) Option {
+	return waitTimeout(timeout)
+}
@@ -0,0 +1,77 @@
+//
+//  clock.go
+//  clock
+//
+//  Created by d-exclaimation on 1:10 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by Jet and Task, which can be replaced for testing
+type Clock interface {
+	// Now return the current time
+	Now() time.Time
+
+	// After returns a channel that receive the current time once the duration elapsed
+	After(d time.Duration) <-chan time.Time
+
+	// Sleep blocks until the duration elapsed
+	Sleep(d time.Duration)
+}
+
+// real is the Clock backed by the time package
+type real struct{}
+
+func (r real) Now() time.Time {
+	return time.Now()
+}
+
+func (r real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (r real) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// Real return the Clock backed by the time package
+func Real() Clock {
+	return real{}
+}
+
+var (
+	// mutex guards the current default
+	mutex sync.RWMutex
+
+	// current is the default Clock
+	current Clock = real{}
+)
+
+// Default return the Clock captured by every new Jet and Task
+func Default() Clock {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
+
+// SetDefault replaces the Clock captured by every new Jet and Task, and return a function to restore the previous one.
+//
+//  vc := clocktest.NewVirtual(time.Now())
+//  defer clock.SetDefault(vc)()
+func SetDefault(c Clock) func() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	prev := current
+	current = c
+	return func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		current = prev
+	}
+}
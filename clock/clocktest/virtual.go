@@ -0,0 +1,247 @@
+//
+//  virtual.go
+//  clocktest
+//
+//  Created by d-exclaimation on 1:32 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package clocktest
+
+import (
+	"bytes"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// module is the prefix of every function in gocurrent, whose goroutines may react to the clock
+const module = "github.com/d-exclaimation/gocurrent/"
+
+// timer is a pending wake up in virtual time
+type timer struct {
+	deadline time.Time
+	order    int
+	ch       chan time.Time
+}
+
+// Virtual is a Clock where time only moves forward through Advance and RunUntilIdle.
+//
+//  vc := clocktest.NewVirtual(time.Unix(0, 0))
+//  defer clock.SetDefault(vc)()
+//  jt := jet.Debounce(source, time.Second)
+//  source.Up(1)
+//  vc.Advance(time.Second)
+//
+// Timers with the same deadline fire in the order they were created.
+//
+// Between firing timers, the clock waits until every goroutine that may react to it is blocked, so the goroutines
+// woken by a timer always register their next timer before time moves on. Those are the goroutines that called the
+// clock and the ones running gocurrent code, while unrelated goroutines and other tests are ignored. Use BlockUntil to
+// wait for goroutines started by the test to block on the clock before advancing.
+type Virtual struct {
+	// mutex guards the current time and timers
+	mutex sync.Mutex
+
+	// registered is signalled whenever a timer is created
+	registered *sync.Cond
+
+	// now is the current virtual time
+	now time.Time
+
+	// timers are the pending wake ups
+	timers []*timer
+
+	// created is the counter for ordering timers with the same deadline
+	created int
+
+	// users are the ids of goroutines that called the clock
+	users map[string]bool
+}
+
+// NewVirtual instantiate a new Virtual clock starting at the given time
+func NewVirtual(start time.Time) *Virtual {
+	v := &Virtual{now: start, users: make(map[string]bool)}
+	v.registered = sync.NewCond(&v.mutex)
+	return v
+}
+
+// Now return the current virtual time
+func (v *Virtual) Now() time.Time {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.users[goroutine()] = true
+	return v.now
+}
+
+// After returns a channel that receive the virtual time once it has been advanced by the duration
+func (v *Virtual) After(d time.Duration) <-chan time.Time {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.users[goroutine()] = true
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- v.now
+		return ch
+	}
+	v.created++
+	v.timers = append(v.timers, &timer{
+		deadline: v.now.Add(d),
+		order:    v.created,
+		ch:       ch,
+	})
+	sort.Slice(v.timers, func(i, j int) bool {
+		if v.timers[i].deadline.Equal(v.timers[j].deadline) {
+			return v.timers[i].order < v.timers[j].order
+		}
+		return v.timers[i].deadline.Before(v.timers[j].deadline)
+	})
+	v.registered.Broadcast()
+	return ch
+}
+
+// Sleep blocks until the virtual time has been advanced by the duration
+func (v *Virtual) Sleep(d time.Duration) {
+	<-v.After(d)
+}
+
+// Pending return the number of timers that have not fired
+func (v *Virtual) Pending() int {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return len(v.timers)
+}
+
+// BlockUntil blocks until at least n timers are pending, i.e. goroutines are waiting on After or Sleep
+func (v *Virtual) BlockUntil(n int) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	for len(v.timers) < n {
+		v.registered.Wait()
+	}
+}
+
+// Advance moves the virtual time forward, firing every timer due in order and letting woken goroutines run in between
+func (v *Virtual) Advance(d time.Duration) {
+	v.settle()
+	v.mutex.Lock()
+	target := v.now.Add(d)
+	v.mutex.Unlock()
+
+	for v.fire(target) {
+	}
+
+	v.mutex.Lock()
+	if v.now.Before(target) {
+		v.now = target
+	}
+	v.mutex.Unlock()
+	v.settle()
+}
+
+// RunUntilIdle fires every pending timer in order, including the ones created while running, until none are left
+func (v *Virtual) RunUntilIdle() {
+	v.settle()
+	for {
+		v.mutex.Lock()
+		if len(v.timers) == 0 {
+			v.mutex.Unlock()
+			return
+		}
+		next := v.timers[0].deadline
+		v.mutex.Unlock()
+		v.fire(next)
+	}
+}
+
+// fire moves the virtual time to the earliest timer due by the target and fire it, and return false if there is none
+func (v *Virtual) fire(target time.Time) bool {
+	v.mutex.Lock()
+	if len(v.timers) == 0 || v.timers[0].deadline.After(target) {
+		v.mutex.Unlock()
+		return false
+	}
+	next := v.timers[0]
+	v.timers = v.timers[1:]
+	v.now = next.deadline
+	next.ch <- next.deadline
+	v.mutex.Unlock()
+
+	v.settle()
+	return true
+}
+
+// settle waits until every goroutine that may react to the clock, other than the caller, is blocked,
+// so goroutines woken by a timer finished reacting to it without relying on how long that takes
+func (v *Virtual) settle() {
+	for yields := 1; v.busy(); yields *= 2 {
+		// Back off between scans, as each one stops the world
+		for i := 0; i < yields && i < 64; i++ {
+			runtime.Gosched()
+		}
+	}
+}
+
+// busy indicates whether any goroutine that may react to the clock, other than the caller, is running or waiting to
+// run, and forget the users that exited
+func (v *Virtual) busy() bool {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	alive := make(map[string]bool, len(v.users))
+	res := false
+
+	// The first goroutine is the caller
+	for i, stack := range bytes.Split(buf, []byte("\n\n")) {
+		header := strings.SplitN(string(stack), " ", 3)
+		if i == 0 || len(header) < 3 || header[0] != "goroutine" {
+			continue
+		}
+		id := header[1]
+
+		// Only the frames tell whether it runs gocurrent code, not the goroutine that created it
+		frames := stack
+		if end := bytes.Index(frames, []byte("\ncreated by ")); end >= 0 {
+			frames = frames[:end]
+		}
+		if v.users[id] {
+			alive[id] = true
+		} else if !bytes.Contains(frames, []byte(module)) || bytes.Contains(frames, []byte("testing.tRunner(")) {
+			continue
+		}
+		start, end := bytes.IndexByte(stack, '['), bytes.IndexByte(stack, ']')
+		if start < 0 || end < start {
+			continue
+		}
+		status := string(stack[start+1 : end])
+		for _, state := range []string{"running", "runnable", "preempted"} {
+			if strings.HasPrefix(status, state) {
+				res = true
+			}
+		}
+	}
+	v.users = alive
+	return res
+}
+
+// goroutine return the id of the calling goroutine
+func goroutine() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	header := strings.SplitN(string(buf), " ", 3)
+	if len(header) < 2 {
+		return ""
+	}
+	return header[1]
+}
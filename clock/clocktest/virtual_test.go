@@ -0,0 +1,104 @@
+package clocktest_test
+
+import (
+	"crypto/rand"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+)
+
+func TestAdvanceOrder(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	second := vc.After(2 * time.Second)
+	first := vc.After(time.Second)
+	tie := vc.After(time.Second)
+
+	vc.Advance(time.Second)
+	if at := <-first; !at.Equal(time.Unix(1, 0)) {
+		t.Fatalf("expected to fire at 1s, got %v", at)
+	}
+	if at := <-tie; !at.Equal(time.Unix(1, 0)) {
+		t.Fatalf("expected to fire at 1s, got %v", at)
+	}
+	select {
+	case <-second:
+		t.Fatal("expected the later timer to wait")
+	default:
+	}
+	if vc.Pending() != 1 {
+		t.Fatalf("expected 1 pending, got %d", vc.Pending())
+	}
+}
+
+func TestAdvanceWakesChains(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	var ticks int32
+	go func() {
+		for {
+			vc.Sleep(time.Second)
+			atomic.AddInt32(&ticks, 1)
+		}
+	}()
+	vc.BlockUntil(1)
+
+	// Each tick registers the next sleep before time moves on
+	vc.Advance(5 * time.Second)
+	if n := atomic.LoadInt32(&ticks); n != 5 {
+		t.Fatalf("expected 5 ticks, got %d", n)
+	}
+	if !vc.Now().Equal(time.Unix(5, 0)) {
+		t.Fatalf("expected 5s, got %v", vc.Now())
+	}
+}
+
+func TestRunUntilIdle(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	done := make(chan time.Time, 1)
+	go func() {
+		vc.Sleep(time.Second)
+		vc.Sleep(time.Hour)
+		done <- vc.Now()
+	}()
+	vc.BlockUntil(1)
+	vc.RunUntilIdle()
+	if at := <-done; !at.Equal(time.Unix(3601, 0)) {
+		t.Fatalf("expected 1h1s, got %v", at)
+	}
+}
+
+func TestBlockUntil(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	for i := 0; i < 3; i++ {
+		go vc.Sleep(time.Second)
+	}
+	vc.BlockUntil(3)
+	if vc.Pending() != 3 {
+		t.Fatalf("expected 3 pending, got %d", vc.Pending())
+	}
+}
+
+func TestAdvanceIgnoresUnrelated(t *testing.T) {
+	// A goroutine kept busy outside gocurrent that never uses the clock
+	pr, pw := io.Pipe()
+	go io.Copy(pw, rand.Reader)
+	go io.Copy(io.Discard, pr)
+	defer pr.Close()
+
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	fired := vc.After(time.Second)
+	done := make(chan struct{})
+	go func() {
+		vc.Advance(time.Second)
+		vc.RunUntilIdle()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Advance waited for an unrelated goroutine")
+	}
+	<-fired
+}
@@ -0,0 +1,169 @@
+//
+//  hub.go
+//  hub
+//
+//  Created by d-exclaimation on 6:20 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package hub
+
+import (
+	"github.com/d-exclaimation/gocurrent/streaming"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"sort"
+	"sync"
+)
+
+// topic is a Jet with the number of subscribers
+type topic struct {
+	jt          *jet.Jet
+	subscribers int
+}
+
+// Hub is a keyed publish-subscribe broker where each topic (or pattern) is backed by a Jet.
+//
+//  hb := hub.New()
+//  sub := hb.Subscribe("orders.*")
+//  defer sub.Unsubscribe()
+//  hb.Publish("orders.created", order)
+//
+// Topics are created lazily on the first subscriber and closed once the last subscriber left.
+type Hub struct {
+	// mutex guards the topics and patterns
+	mutex sync.Mutex
+
+	// topics are the exact topics
+	topics map[string]*topic
+
+	// patterns are the wildcard topics
+	patterns map[string]*topic
+
+	// opts are the Option for each topic's Jet
+	opts []jet.Option
+}
+
+// New instantiate a new Hub with the Option used for each topic's Jet
+func New(opts ...jet.Option) *Hub {
+	return &Hub{
+		topics:   make(map[string]*topic),
+		patterns: make(map[string]*topic),
+		opts:     opts,
+	}
+}
+
+// registry return the map where the key belongs
+func (h *Hub) registry(key string) map[string]*topic {
+	if isPattern(key) {
+		return h.patterns
+	}
+	return h.topics
+}
+
+// Publish pushes a value to the topic and every pattern matching it, dropped if there are no subscribers
+func (h *Hub) Publish(key string, data Any) {
+	h.mutex.Lock()
+	var targets []*jet.Jet
+	if t, ok := h.topics[key]; ok {
+		targets = append(targets, t.jt)
+	}
+	for pattern, t := range h.patterns {
+		if match(pattern, key) {
+			targets = append(targets, t.jt)
+		}
+	}
+	h.mutex.Unlock()
+
+	// Push outside the lock, so subscribers can unsubscribe while receiving
+	for _, jt := range targets {
+		jt.Up(data)
+	}
+}
+
+// Subscribe registers a consumer to the topic or pattern, creating the topic's Jet if needed
+func (h *Hub) Subscribe(key string) *Subscription {
+	h.mutex.Lock()
+	registry := h.registry(key)
+	t, ok := registry[key]
+	if !ok {
+		t = &topic{jt: jet.New(h.opts...)}
+		registry[key] = t
+	}
+	t.subscribers++
+	h.mutex.Unlock()
+
+	return &Subscription{
+		hub: h,
+		key: key,
+		jt:  t.jt,
+		ch:  t.jt.Sink(),
+	}
+}
+
+// unsubscribe detach the consumer and close the topic if it was the last subscriber
+func (h *Hub) unsubscribe(key string, jt *jet.Jet, ch streaming.Consumer) {
+	h.mutex.Lock()
+	registry := h.registry(key)
+	last := false
+	if t, ok := registry[key]; ok && t.jt == jt {
+		t.subscribers--
+		if t.subscribers <= 0 {
+			delete(registry, key)
+			last = true
+		}
+	}
+	h.mutex.Unlock()
+
+	// Drain while detaching, so the Jet is never blocked on an abandoned channel
+	go func() {
+		for range ch {
+		}
+	}()
+	_ = jt.Detach(ch)
+	if last {
+		jt.Close()
+	}
+}
+
+// Topics return all the active topics and patterns in order
+func (h *Hub) Topics() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	res := make([]string, 0, len(h.topics)+len(h.patterns))
+	for key := range h.topics {
+		res = append(res, key)
+	}
+	for key := range h.patterns {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Subscription is a consumer of a topic or pattern in a Hub
+type Subscription struct {
+	hub  *Hub
+	key  string
+	jt   *jet.Jet
+	ch   streaming.Consumer
+	once sync.Once
+}
+
+// Topic return the topic or pattern subscribed to
+func (s *Subscription) Topic() string {
+	return s.key
+}
+
+// Channel return the consumer channel, which closes once unsubscribed
+func (s *Subscription) Channel() streaming.Consumer {
+	return s.ch
+}
+
+// Unsubscribe detach the consumer channel, and close the topic if no subscribers are left
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.hub.unsubscribe(s.key, s.jt, s.ch)
+	})
+}
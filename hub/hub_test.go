@@ -0,0 +1,119 @@
+package hub_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/hub"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+// receive fails the test if the subscription does not get a value in time
+func receive(t *testing.T, sub *hub.Subscription) Any {
+	t.Helper()
+	select {
+	case value, ok := <-sub.Channel():
+		if !ok {
+			t.Fatalf("subscription to %q closed", sub.Topic())
+		}
+		return value
+	case <-time.After(time.Second):
+		t.Fatalf("subscription to %q never received", sub.Topic())
+	}
+	return nil
+}
+
+// closes fails the test if the subscription's channel does not close in time
+func closes(t *testing.T, sub *hub.Subscription) {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-sub.Channel():
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("subscription to %q never closed", sub.Topic())
+		}
+	}
+}
+
+func TestPublishPatterns(t *testing.T) {
+	hb := hub.New()
+	exact := hb.Subscribe("orders.created")
+	single := hb.Subscribe("orders.*")
+	rest := hb.Subscribe("orders.>")
+	defer exact.Unsubscribe()
+	defer single.Unsubscribe()
+	defer rest.Unsubscribe()
+
+	hb.Publish("orders.created", 1)
+	if receive(t, exact) != 1 || receive(t, single) != 1 || receive(t, rest) != 1 {
+		t.Fatal("expected every matching subscription to receive")
+	}
+
+	// Only "orders.>" matches deeper topics, and nothing else is delivered in between
+	hb.Publish("orders.eu.created", 2)
+	hb.Publish("orders", 3)
+	hb.Publish("orders.deleted", 4)
+	if value := receive(t, rest); value != 2 {
+		t.Fatalf("expected 2, got %v", value)
+	}
+	if value := receive(t, rest); value != 4 {
+		t.Fatalf("expected 4 as orders.> does not match orders, got %v", value)
+	}
+	if value := receive(t, single); value != 4 {
+		t.Fatalf("expected 4, got %v", value)
+	}
+}
+
+func TestLazyTopics(t *testing.T) {
+	hb := hub.New()
+	if len(hb.Topics()) != 0 {
+		t.Fatalf("expected no topics before subscribing, got %v", hb.Topics())
+	}
+
+	// Publishing without subscribers is dropped and creates nothing
+	hb.Publish("orders.created", 1)
+	if len(hb.Topics()) != 0 {
+		t.Fatalf("expected no topics after publishing, got %v", hb.Topics())
+	}
+
+	first := hb.Subscribe("orders.created")
+	second := hb.Subscribe("orders.created")
+	pattern := hb.Subscribe("orders.*")
+	if topics := hb.Topics(); !reflect.DeepEqual(topics, []string{"orders.*", "orders.created"}) {
+		t.Fatalf("expected a topic per key, got %v", topics)
+	}
+
+	first.Unsubscribe()
+	closes(t, first)
+	if topics := hb.Topics(); !reflect.DeepEqual(topics, []string{"orders.*", "orders.created"}) {
+		t.Fatalf("expected the topic to stay with a subscriber left, got %v", topics)
+	}
+
+	second.Unsubscribe()
+	pattern.Unsubscribe()
+	closes(t, second)
+	closes(t, pattern)
+	if len(hb.Topics()) != 0 {
+		t.Fatalf("expected every topic torn down, got %v", hb.Topics())
+	}
+}
+
+func TestResubscribe(t *testing.T) {
+	hb := hub.New()
+	old := hb.Subscribe("orders.created")
+	old.Unsubscribe()
+	old.Unsubscribe()
+	closes(t, old)
+
+	sub := hb.Subscribe("orders.created")
+	defer sub.Unsubscribe()
+	hb.Publish("orders.created", 1)
+	if value := receive(t, sub); value != 1 {
+		t.Fatalf("expected the new topic to deliver, got %v", value)
+	}
+}
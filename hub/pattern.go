@@ -0,0 +1,51 @@
+//
+//  pattern.go
+//  hub
+//
+//  Created by d-exclaimation on 6:48 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package hub
+
+import "strings"
+
+const (
+	// separator splits a topic into segments
+	separator = "."
+
+	// single is the wildcard segment matching exactly one segment
+	single = "*"
+
+	// rest is the wildcard segment matching one or more trailing segments
+	rest = ">"
+)
+
+// isPattern indicates whether the topic contains any wildcard segment
+func isPattern(topic string) bool {
+	for _, segment := range strings.Split(topic, separator) {
+		if segment == single || segment == rest {
+			return true
+		}
+	}
+	return false
+}
+
+// match indicates whether the topic is matched by the pattern, i.e. "orders.*" matches "orders.created"
+// and "orders.>" matches "orders.eu.created"
+func match(pattern, topic string) bool {
+	patterns := strings.Split(pattern, separator)
+	topics := strings.Split(topic, separator)
+	for i, segment := range patterns {
+		if segment == rest {
+			return len(topics) > i
+		}
+		if i >= len(topics) {
+			return false
+		}
+		if segment != single && segment != topics[i] {
+			return false
+		}
+	}
+	return len(patterns) == len(topics)
+}
@@ -0,0 +1,43 @@
+package hub
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		topic   string
+		matched bool
+	}{
+		{"orders.*", "orders.created", true},
+		{"orders.*", "orders", false},
+		{"orders.*", "orders.eu.created", false},
+		{"*.created", "orders.created", true},
+		{"*.created", "orders.deleted", false},
+		{"orders.>", "orders.created", true},
+		{"orders.>", "orders.eu.created", true},
+		{"orders.>", "orders", false},
+		{"orders.*.>", "orders.eu.created", true},
+		{"orders.*.>", "orders.eu", false},
+		{">", "orders", true},
+		{"orders.created", "orders.created", true},
+	}
+	for _, c := range cases {
+		if res := match(c.pattern, c.topic); res != c.matched {
+			t.Errorf("expected match(%q, %q) to be %v", c.pattern, c.topic, c.matched)
+		}
+	}
+}
+
+func TestIsPattern(t *testing.T) {
+	for topic, expected := range map[string]bool{
+		"orders":         false,
+		"orders.created": false,
+		"orders.*":       true,
+		"orders.>":       true,
+		"orders.a*":      false,
+	} {
+		if isPattern(topic) != expected {
+			t.Errorf("expected isPattern(%q) to be %v", topic, expected)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+//
+//  leakcheck.go
+//  leakcheck
+//
+//  Created by d-exclaimation on 5:18 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package leakcheck
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// module is the prefix of every function in gocurrent
+	module = "github.com/d-exclaimation/gocurrent/"
+
+	// self is the prefix of every function in this package
+	self = module + "leakcheck."
+)
+
+// Check snapshots the goroutines running gocurrent code, and return a function that fails the test
+// if any new one is still running after a grace period.
+//
+//  func TestStream(t *testing.T) {
+//      defer leakcheck.Check(t)()
+//      jt := jet.New()
+//      defer jt.Close()
+//  }
+func Check(t testing.TB) func() {
+	return CheckWithin(t, time.Second)
+}
+
+// CheckWithin is Check with a custom grace period for goroutines to finish their teardown
+func CheckWithin(t testing.TB, grace time.Duration) func() {
+	before := make(map[string]bool)
+	for id := range goroutines() {
+		before[id] = true
+	}
+
+	return func() {
+		t.Helper()
+		deadline := time.Now().Add(grace)
+		for {
+			leaks := leaked(before)
+			if len(leaks) == 0 {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Errorf("leakcheck: %d goroutine(s) leaked\n\n%s", len(leaks), strings.Join(leaks, "\n\n"))
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+// leaked return the stacks of goroutines running gocurrent code that are not in the snapshot
+func leaked(before map[string]bool) []string {
+	var res []string
+	for id, stack := range goroutines() {
+		if !before[id] {
+			res = append(res, stack)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// goroutines return the stacks of all goroutines running gocurrent code by their id,
+// excluding tests and this package
+func goroutines() map[string]string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	res := make(map[string]string)
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		header := strings.SplitN(stack, " ", 3)
+		if len(header) < 3 || header[0] != "goroutine" {
+			continue
+		}
+		if !strings.Contains(stack, module) || strings.Contains(stack, self) || strings.Contains(stack, "testing.tRunner(") {
+			continue
+		}
+		res[header[1]] = stack
+	}
+	return res
+}
@@ -0,0 +1,67 @@
+package leakcheck_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/leakcheck"
+)
+
+// recorder is a testing.TB that records failures instead of failing
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestCheckLeak(t *testing.T) {
+	r := &recorder{TB: t}
+	stop := make(chan struct{})
+	defer close(stop)
+
+	check := leakcheck.CheckWithin(r, 50*time.Millisecond)
+	go func() {
+		<-stop
+	}()
+	check()
+
+	if len(r.failures) != 1 {
+		t.Fatalf("expected the leak to be reported, got %v", r.failures)
+	}
+}
+
+func TestCheckClean(t *testing.T) {
+	r := &recorder{TB: t}
+	done := make(chan struct{})
+
+	check := leakcheck.CheckWithin(r, time.Second)
+	go func() {
+		close(done)
+	}()
+	<-done
+	check()
+
+	if len(r.failures) != 0 {
+		t.Fatalf("expected no leak, got %v", r.failures)
+	}
+}
+
+func TestCheckGrace(t *testing.T) {
+	r := &recorder{TB: t}
+
+	check := leakcheck.CheckWithin(r, time.Second)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+	}()
+	check()
+
+	if len(r.failures) != 0 {
+		t.Fatalf("expected a goroutine finishing within the grace period to pass, got %v", r.failures)
+	}
+}
@@ -0,0 +1,21 @@
+//
+//  expvar.go
+//  observe
+//
+//  Created by d-exclaimation on 11:05 AM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package observe
+
+import "expvar"
+
+// PublishExpvar exports the Memory metrics as an expvar variable under the name, which is served by expvar's handler
+// if the program registered it.
+//
+// Note: Like expvar.Publish, it panics if the name is already used.
+func PublishExpvar(name string, m *Memory) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return m.Snapshot()
+	}))
+}
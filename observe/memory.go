@@ -0,0 +1,212 @@
+//
+//  memory.go
+//  observe
+//
+//  Created by d-exclaimation on 10:40 AM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package observe
+
+import (
+	"sync"
+	"time"
+)
+
+// Buckets are the upper bounds of the Task duration histogram
+var Buckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// TaskStats are the aggregated metrics of Tasks with the same name
+type TaskStats struct {
+	// Started is the number of runs started
+	Started int64
+
+	// Succeeded is the number of runs that acquired a value
+	Succeeded int64
+
+	// Failed is the number of runs that acquired an error, including cancellation
+	Failed int64
+
+	// TotalDuration is the sum of all settled runs' duration
+	TotalDuration time.Duration
+
+	// MaxDuration is the longest settled run
+	MaxDuration time.Duration
+
+	// Histogram is the number of settled runs within each of Buckets, with the last one for anything above
+	Histogram []int64
+}
+
+// Running return the number of runs started but not settled
+func (s TaskStats) Running() int64 {
+	return s.Started - s.Succeeded - s.Failed
+}
+
+// JetStats are the aggregated metrics of Jets with the same name
+type JetStats struct {
+	// Emitted is the number of values emitted
+	Emitted int64
+
+	// Delivered is the number of values received by all consumers
+	Delivered int64
+
+	// Dropped is the number of values discarded
+	Dropped int64
+
+	// Subscribers is the latest number of subscribers
+	Subscribers int
+
+	// BufferUsed is the latest number of values waiting in the upstream
+	BufferUsed int
+
+	// BufferCapacity is the capacity of the upstream
+	BufferCapacity int
+}
+
+// Snapshot is a copy of all metrics at a point in time
+type Snapshot struct {
+	// Tasks are the metrics by Task name
+	Tasks map[string]TaskStats
+
+	// Jets are the metrics by Jet name
+	Jets map[string]JetStats
+}
+
+// Memory is an Observer that aggregates metrics in memory by name
+type Memory struct {
+	// mutex guards the metrics
+	mutex sync.Mutex
+
+	// tasks are the metrics by Task name
+	tasks map[string]*TaskStats
+
+	// jets are the metrics by Jet name
+	jets map[string]*JetStats
+}
+
+// NewMemory instantiate a new Memory Observer
+func NewMemory() *Memory {
+	return &Memory{
+		tasks: make(map[string]*TaskStats),
+		jets:  make(map[string]*JetStats),
+	}
+}
+
+// task return the metrics of the Task name (must hold the mutex)
+func (m *Memory) task(name string) *TaskStats {
+	stats, ok := m.tasks[name]
+	if !ok {
+		stats = &TaskStats{Histogram: make([]int64, len(Buckets)+1)}
+		m.tasks[name] = stats
+	}
+	return stats
+}
+
+// jet return the metrics of the Jet name (must hold the mutex)
+func (m *Memory) jet(name string) *JetStats {
+	stats, ok := m.jets[name]
+	if !ok {
+		stats = &JetStats{}
+		m.jets[name] = stats
+	}
+	return stats
+}
+
+// TaskStarted counts a started run of the Task name
+func (m *Memory) TaskStarted(name string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.task(name).Started++
+}
+
+// TaskSettled counts a settled run of the Task name by outcome, and records its duration in the histogram
+func (m *Memory) TaskSettled(name string, duration time.Duration, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	stats := m.task(name)
+	if err != nil {
+		stats.Failed++
+	} else {
+		stats.Succeeded++
+	}
+	stats.TotalDuration += duration
+	if duration > stats.MaxDuration {
+		stats.MaxDuration = duration
+	}
+	bucket := len(Buckets)
+	for i, bound := range Buckets {
+		if duration <= bound {
+			bucket = i
+			break
+		}
+	}
+	stats.Histogram[bucket]++
+}
+
+// JetEmitted counts an emitted value of the Jet name and its deliveries to every receiver
+func (m *Memory) JetEmitted(name string, receivers int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	stats := m.jet(name)
+	stats.Emitted++
+	stats.Delivered += int64(receivers)
+}
+
+// SubscriberAttached records the current number of subscribers of the Jet name
+func (m *Memory) SubscriberAttached(name string, subscribers int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.jet(name).Subscribers = subscribers
+}
+
+// SubscriberDetached records the current number of subscribers of the Jet name
+func (m *Memory) SubscriberDetached(name string, subscribers int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.jet(name).Subscribers = subscribers
+}
+
+// BufferOccupancy records the latest upstream buffer usage of the Jet name
+func (m *Memory) BufferOccupancy(name string, used int, capacity int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	stats := m.jet(name)
+	stats.BufferUsed = used
+	stats.BufferCapacity = capacity
+}
+
+// Dropped counts the discarded values of the Jet name
+func (m *Memory) Dropped(name string, count int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.jet(name).Dropped += int64(count)
+}
+
+// Snapshot return a copy of all metrics
+func (m *Memory) Snapshot() Snapshot {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	res := Snapshot{
+		Tasks: make(map[string]TaskStats, len(m.tasks)),
+		Jets:  make(map[string]JetStats, len(m.jets)),
+	}
+	for name, stats := range m.tasks {
+		copied := *stats
+		copied.Histogram = append([]int64{}, stats.Histogram...)
+		res.Tasks[name] = copied
+	}
+	for name, stats := range m.jets {
+		res.Jets[name] = *stats
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package observe_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/observe"
+)
+
+func TestMemoryTasks(t *testing.T) {
+	m := observe.NewMemory()
+	for i := 0; i < 3; i++ {
+		m.TaskStarted("fetch")
+	}
+	m.TaskSettled("fetch", time.Millisecond, nil)
+	m.TaskSettled("fetch", 20*time.Millisecond, errors.New("failed"))
+
+	stats := m.Snapshot().Tasks["fetch"]
+	if stats.Started != 3 || stats.Succeeded != 1 || stats.Failed != 1 || stats.Running() != 1 {
+		t.Fatalf("unexpected counters %+v", stats)
+	}
+	if stats.TotalDuration != 21*time.Millisecond || stats.MaxDuration != 20*time.Millisecond {
+		t.Fatalf("unexpected durations %+v", stats)
+	}
+
+	// A duration on a bound belongs to that bucket
+	if stats.Histogram[0] != 1 || stats.Histogram[3] != 1 {
+		t.Fatalf("expected 1ms in the first bucket and 20ms in the 50ms one, got %v", stats.Histogram)
+	}
+	m.TaskSettled("fetch", time.Minute, nil)
+	if stats := m.Snapshot().Tasks["fetch"]; stats.Histogram[len(observe.Buckets)] != 1 {
+		t.Fatalf("expected a minute in the overflow bucket, got %v", stats.Histogram)
+	}
+}
+
+func TestMemoryJets(t *testing.T) {
+	m := observe.NewMemory()
+	m.SubscriberAttached("events", 1)
+	m.SubscriberAttached("events", 2)
+	m.JetEmitted("events", 2)
+	m.JetEmitted("events", 1)
+	m.SubscriberDetached("events", 1)
+	m.BufferOccupancy("events", 1, 4)
+	m.Dropped("events", 2)
+
+	stats := m.Snapshot().Jets["events"]
+	expected := observe.JetStats{Emitted: 2, Delivered: 3, Dropped: 2, Subscribers: 1, BufferUsed: 1, BufferCapacity: 4}
+	if stats != expected {
+		t.Fatalf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestMemorySnapshotCopies(t *testing.T) {
+	m := observe.NewMemory()
+	m.TaskSettled("fetch", time.Millisecond, nil)
+	snap := m.Snapshot()
+	m.TaskSettled("fetch", time.Millisecond, nil)
+	if snap.Tasks["fetch"].Succeeded != 1 || snap.Tasks["fetch"].Histogram[0] != 1 {
+		t.Fatalf("expected the snapshot to be unaffected by later metrics, got %+v", snap.Tasks["fetch"])
+	}
+}
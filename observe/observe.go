@@ -0,0 +1,84 @@
+//
+//  observe.go
+//  observe
+//
+//  Created by d-exclaimation on 10:12 AM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package observe
+
+import (
+	"sync"
+	"time"
+)
+
+// Observer is notified by Jet and Task about their lifecycle for metrics.
+//
+// Methods are called synchronously from the Jet's and Task's goroutine, so they must not block.
+type Observer interface {
+	// TaskStarted is called when a Task starts running
+	TaskStarted(name string)
+
+	// TaskSettled is called when a started Task acquired a value, error, or was cancelled
+	TaskSettled(name string, duration time.Duration, err error)
+
+	// JetEmitted is called when a Jet emits a value to the number of receivers
+	JetEmitted(name string, receivers int)
+
+	// SubscriberAttached is called when a consumer registered to a Jet, with the current number of subscribers
+	SubscriberAttached(name string, subscribers int)
+
+	// SubscriberDetached is called when a consumer is removed from a Jet, with the current number of subscribers
+	SubscriberDetached(name string, subscribers int)
+
+	// BufferOccupancy is called when a Jet emits with the number of values still buffered in its upstream
+	BufferOccupancy(name string, used int, capacity int)
+
+	// Dropped is called when values pushed into a Jet are discarded
+	Dropped(name string, count int)
+}
+
+// nop is the Observer that ignores everything
+type nop struct{}
+
+func (n nop) TaskStarted(string)                       {}
+func (n nop) TaskSettled(string, time.Duration, error) {}
+func (n nop) JetEmitted(string, int)                   {}
+func (n nop) SubscriberAttached(string, int)           {}
+func (n nop) SubscriberDetached(string, int)           {}
+func (n nop) BufferOccupancy(string, int, int)         {}
+func (n nop) Dropped(string, int)                      {}
+
+// Nop return the Observer that ignores everything
+func Nop() Observer {
+	return nop{}
+}
+
+var (
+	// mutex guards the current default
+	mutex sync.RWMutex
+
+	// current is the default Observer
+	current Observer = nop{}
+)
+
+// Default return the Observer captured by every new Jet and Task
+func Default() Observer {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
+
+// SetDefault replaces the Observer captured by every new Jet and Task, and return a function to restore the previous one.
+func SetDefault(o Observer) func() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	prev := current
+	current = o
+	return func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		current = prev
+	}
+}
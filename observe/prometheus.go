@@ -0,0 +1,118 @@
+//
+//  prometheus.go
+//  observe
+//
+//  Created by d-exclaimation on 11:22 AM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package observe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WritePrometheus writes the Memory metrics in the Prometheus text exposition format
+func WritePrometheus(w io.Writer, m *Memory) error {
+	snap := m.Snapshot()
+	out := bufio.NewWriter(w)
+
+	tasks := make([]string, 0, len(snap.Tasks))
+	for name := range snap.Tasks {
+		tasks = append(tasks, name)
+	}
+	sort.Strings(tasks)
+
+	jets := make([]string, 0, len(snap.Jets))
+	for name := range snap.Jets {
+		jets = append(jets, name)
+	}
+	sort.Strings(jets)
+
+	header(out, "gocurrent_task_started_total", "counter", "Number of Task runs started")
+	for _, name := range tasks {
+		sample(out, "gocurrent_task_started_total", label(name), float64(snap.Tasks[name].Started))
+	}
+	header(out, "gocurrent_task_settled_total", "counter", "Number of Task runs settled by outcome")
+	for _, name := range tasks {
+		stats := snap.Tasks[name]
+		sample(out, "gocurrent_task_settled_total", label(name, "outcome", "success"), float64(stats.Succeeded))
+		sample(out, "gocurrent_task_settled_total", label(name, "outcome", "failure"), float64(stats.Failed))
+	}
+	header(out, "gocurrent_task_running", "gauge", "Number of Task runs in flight")
+	for _, name := range tasks {
+		sample(out, "gocurrent_task_running", label(name), float64(snap.Tasks[name].Running()))
+	}
+	header(out, "gocurrent_task_duration_seconds", "histogram", "Duration of settled Task runs")
+	for _, name := range tasks {
+		stats := snap.Tasks[name]
+		cumulative := int64(0)
+		for i, bound := range Buckets {
+			cumulative += stats.Histogram[i]
+			sample(out, "gocurrent_task_duration_seconds_bucket", label(name, "le", format(bound.Seconds())), float64(cumulative))
+		}
+		cumulative += stats.Histogram[len(Buckets)]
+		sample(out, "gocurrent_task_duration_seconds_bucket", label(name, "le", "+Inf"), float64(cumulative))
+		sample(out, "gocurrent_task_duration_seconds_sum", label(name), stats.TotalDuration.Seconds())
+		sample(out, "gocurrent_task_duration_seconds_count", label(name), float64(cumulative))
+	}
+
+	header(out, "gocurrent_jet_emitted_total", "counter", "Number of values emitted by Jets")
+	for _, name := range jets {
+		sample(out, "gocurrent_jet_emitted_total", label(name), float64(snap.Jets[name].Emitted))
+	}
+	header(out, "gocurrent_jet_delivered_total", "counter", "Number of values received by Jet consumers")
+	for _, name := range jets {
+		sample(out, "gocurrent_jet_delivered_total", label(name), float64(snap.Jets[name].Delivered))
+	}
+	header(out, "gocurrent_jet_dropped_total", "counter", "Number of values discarded by Jets")
+	for _, name := range jets {
+		sample(out, "gocurrent_jet_dropped_total", label(name), float64(snap.Jets[name].Dropped))
+	}
+	header(out, "gocurrent_jet_subscribers", "gauge", "Number of Jet subscribers")
+	for _, name := range jets {
+		sample(out, "gocurrent_jet_subscribers", label(name), float64(snap.Jets[name].Subscribers))
+	}
+	header(out, "gocurrent_jet_buffer_used", "gauge", "Number of values waiting in Jet upstream buffers")
+	for _, name := range jets {
+		sample(out, "gocurrent_jet_buffer_used", label(name), float64(snap.Jets[name].BufferUsed))
+	}
+	header(out, "gocurrent_jet_buffer_capacity", "gauge", "Capacity of Jet upstream buffers")
+	for _, name := range jets {
+		sample(out, "gocurrent_jet_buffer_capacity", label(name), float64(snap.Jets[name].BufferCapacity))
+	}
+
+	return out.Flush()
+}
+
+// header writes the HELP and TYPE lines of a metric
+func header(out *bufio.Writer, metric, kind, help string) {
+	_, _ = fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", metric, help, metric, kind)
+}
+
+// sample writes a single metric line
+func sample(out *bufio.Writer, metric, labels string, value float64) {
+	_, _ = fmt.Fprintf(out, "%s{%s} %s\n", metric, labels, format(value))
+}
+
+// label formats the name label with additional key value pairs
+func label(name string, pairs ...string) string {
+	labels := []string{fmt.Sprintf(`name="%s"`, escaper.Replace(name))}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, pairs[i], escaper.Replace(pairs[i+1])))
+	}
+	return strings.Join(labels, ",")
+}
+
+// escaper escapes label values as required by the exposition format
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// format writes a float in the shortest representation
+func format(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
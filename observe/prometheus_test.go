@@ -0,0 +1,79 @@
+package observe_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/observe"
+)
+
+// write return the exposition of the Memory
+func write(t *testing.T, m *observe.Memory) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := observe.WritePrometheus(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// contains fails the test unless every line is in the exposition
+func contains(t *testing.T, out string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("expected %q in\n%s", line, out)
+		}
+	}
+}
+
+func TestPrometheusHistogram(t *testing.T) {
+	m := observe.NewMemory()
+	m.TaskStarted("fetch")
+	m.TaskStarted("fetch")
+	m.TaskStarted("fetch")
+	m.TaskSettled("fetch", time.Millisecond, nil)
+	m.TaskSettled("fetch", 20*time.Millisecond, nil)
+	m.TaskSettled("fetch", time.Minute, nil)
+
+	out := write(t, m)
+	contains(t, out,
+		"# TYPE gocurrent_task_duration_seconds histogram",
+		`gocurrent_task_duration_seconds_bucket{name="fetch",le="0.001"} 1`,
+		`gocurrent_task_duration_seconds_bucket{name="fetch",le="0.01"} 1`,
+		`gocurrent_task_duration_seconds_bucket{name="fetch",le="0.05"} 2`,
+		`gocurrent_task_duration_seconds_bucket{name="fetch",le="10"} 2`,
+		`gocurrent_task_duration_seconds_bucket{name="fetch",le="+Inf"} 3`,
+		`gocurrent_task_duration_seconds_sum{name="fetch"} 60.021`,
+		`gocurrent_task_duration_seconds_count{name="fetch"} 3`,
+		`gocurrent_task_started_total{name="fetch"} 3`,
+		`gocurrent_task_settled_total{name="fetch",outcome="success"} 3`,
+		`gocurrent_task_running{name="fetch"} 0`,
+	)
+}
+
+func TestPrometheusJets(t *testing.T) {
+	m := observe.NewMemory()
+	m.JetEmitted("events", 2)
+	m.Dropped("events", 1)
+	m.BufferOccupancy("events", 1, 4)
+
+	contains(t, write(t, m),
+		"# HELP gocurrent_jet_emitted_total Number of values emitted by Jets",
+		"# TYPE gocurrent_jet_emitted_total counter",
+		`gocurrent_jet_emitted_total{name="events"} 1`,
+		`gocurrent_jet_delivered_total{name="events"} 2`,
+		`gocurrent_jet_dropped_total{name="events"} 1`,
+		"# TYPE gocurrent_jet_buffer_used gauge",
+		`gocurrent_jet_buffer_capacity{name="events"} 4`,
+	)
+}
+
+func TestPrometheusEscaping(t *testing.T) {
+	m := observe.NewMemory()
+	m.TaskStarted("a\"b\\c\nd")
+
+	contains(t, write(t, m), `gocurrent_task_started_total{name="a\"b\\c\nd"} 1`)
+}
@@ -0,0 +1,77 @@
+//
+//  encoding.go
+//  option
+//
+//  Created by d-exclaimation on 7:34 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package option
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// null is the JSON encoding of an absent value
+var null = []byte("null")
+
+// MarshalJSON encodes the value if present, or null if absent
+//
+// Note: Some with a value encoded as null (e.g. a nil pointer) decodes back as None
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return null, nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes null as None, or any other value as Some
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), null) {
+		*o = None[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
+
+// MarshalText encodes the Option as its JSON representation
+func (o Option[T]) MarshalText() ([]byte, error) {
+	return o.MarshalJSON()
+}
+
+// UnmarshalText decodes the Option from its JSON representation
+func (o *Option[T]) UnmarshalText(text []byte) error {
+	return o.UnmarshalJSON(text)
+}
+
+// gobOption is the gob shape of an Option
+type gobOption[T any] struct {
+	Value   T
+	Present bool
+}
+
+// GobEncode encodes the Option with gob, which keeps Some with a zero value distinct from None
+func (o Option[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(gobOption[T]{o.value, o.present}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes the Option from gob
+func (o *Option[T]) GobDecode(data []byte) error {
+	var res gobOption[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&res); err != nil {
+		return err
+	}
+	*o = Option[T]{res.Value, res.Present}
+	return nil
+}
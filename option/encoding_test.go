@@ -0,0 +1,43 @@
+package option_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/option"
+)
+
+type record struct {
+	Count option.Option[int]
+	Name  option.Option[string]
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(record{Count: option.Some(0)})
+	if err != nil || string(data) != `{"Count":0,"Name":null}` {
+		t.Fatalf("unexpected encoding %s %v", data, err)
+	}
+	var decoded record
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := decoded.Count.Get(); !ok || value != 0 || decoded.Name.IsSome() {
+		t.Fatalf("unexpected decoding %+v", decoded)
+	}
+}
+
+func TestGob(t *testing.T) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(record{Count: option.Some(0)}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded record
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Count.IsNone() || decoded.Name.IsSome() {
+		t.Fatalf("unexpected decoding %+v", decoded)
+	}
+}
@@ -0,0 +1,104 @@
+//
+//  option.go
+//  option
+//
+//  Created by d-exclaimation on 6:14 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package option
+
+import (
+	"errors"
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+// ErrNone is the error when converting an empty Option into a Try
+var ErrNone = errors.New("option: Option has no value")
+
+// Option is a data structure representing a value that may be absent, which is distinct from a nil value
+type Option[T any] struct {
+	value   T
+	present bool
+}
+
+// Some instantiate a new Option with a value
+func Some[T any](value T) Option[T] {
+	return Option[T]{value, true}
+}
+
+// None instantiate a new Option without a value
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// FromTry instantiate a new Option with the successful value of the Try, or None if failed
+func FromTry[T any](t *try.Try[T]) Option[T] {
+	if !t.IsSuccess() {
+		return None[T]()
+	}
+	return Some(t.Get())
+}
+
+// Get return the value and whether it is present
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.present
+}
+
+// IsSome indicates whether the value is present
+func (o Option[T]) IsSome() bool {
+	return o.present
+}
+
+// IsNone indicates whether the value is absent
+func (o Option[T]) IsNone() bool {
+	return !o.present
+}
+
+// OrElse return the value if present, or the fallback
+func (o Option[T]) OrElse(fallback T) T {
+	if o.present {
+		return o.value
+	}
+	return fallback
+}
+
+// OrElseGet return the value if present, or the computed fallback
+func (o Option[T]) OrElseGet(fallback func() T) T {
+	if o.present {
+		return o.value
+	}
+	return fallback()
+}
+
+// Filter keeps the value only if it met the predicate
+func (o Option[T]) Filter(predicate func(T) bool) Option[T] {
+	if o.present && predicate(o.value) {
+		return o
+	}
+	return None[T]()
+}
+
+// ToTry return a successful Try with the value if present, or a failure with ErrNone
+func (o Option[T]) ToTry() *try.Try[T] {
+	if !o.present {
+		return try.Failure[T](ErrNone)
+	}
+	return try.Success(o.value)
+}
+
+// Map transformed the value of an Option into a new type if present
+func Map[T, K any](o Option[T], transform func(T) K) Option[K] {
+	if !o.present {
+		return None[K]()
+	}
+	return Some(transform(o.value))
+}
+
+// FlatMap transformed the value of an Option into an Option with new type if present
+func FlatMap[T, K any](o Option[T], transform func(T) Option[K]) Option[K] {
+	if !o.present {
+		return None[K]()
+	}
+	return transform(o.value)
+}
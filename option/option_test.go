@@ -0,0 +1,100 @@
+package option_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/option"
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+func TestSomeNone(t *testing.T) {
+	// A zero or nil value is still present
+	if value, ok := option.Some(0).Get(); !ok || value != 0 {
+		t.Fatalf("expected some 0, got %v %v", value, ok)
+	}
+	if res := option.Some[error](nil); res.IsNone() {
+		t.Fatal("expected some nil to be present")
+	}
+
+	none := option.None[int]()
+	if _, ok := none.Get(); ok || none.IsSome() || !none.IsNone() {
+		t.Fatal("expected none to be absent")
+	}
+	if zero := (option.Option[int]{}); zero.IsSome() {
+		t.Fatal("expected the zero Option to be none")
+	}
+	if none.OrElse(1) != 1 || none.OrElseGet(func() int { return 2 }) != 2 {
+		t.Fatal("expected the fallback for none")
+	}
+	if option.Some(3).OrElse(1) != 3 || option.Some(3).OrElseGet(func() int { return 2 }) != 3 {
+		t.Fatal("expected the value for some")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	if value, ok := option.Some(2).Filter(even).Get(); !ok || value != 2 {
+		t.Fatalf("expected some 2, got %v %v", value, ok)
+	}
+	if option.Some(3).Filter(even).IsSome() {
+		t.Fatal("expected none when the predicate failed")
+	}
+
+	called := false
+	option.None[int]().Filter(func(int) bool {
+		called = true
+		return true
+	})
+	if called {
+		t.Fatal("expected the predicate not to be called for none")
+	}
+}
+
+func TestMap(t *testing.T) {
+	if value, ok := option.Map(option.Some(2), strconv.Itoa).Get(); !ok || value != "2" {
+		t.Fatalf("expected some \"2\", got %v %v", value, ok)
+	}
+	if option.Map(option.None[int](), strconv.Itoa).IsSome() {
+		t.Fatal("expected none to stay none")
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	parse := func(s string) option.Option[int] {
+		return option.FromTry(try.New(strconv.Atoi(s)))
+	}
+	if value, ok := option.FlatMap(option.Some("4"), parse).Get(); !ok || value != 4 {
+		t.Fatalf("expected some 4, got %v %v", value, ok)
+	}
+	if option.FlatMap(option.Some("x"), parse).IsSome() {
+		t.Fatal("expected the transform's none")
+	}
+	if option.FlatMap(option.None[string](), parse).IsSome() {
+		t.Fatal("expected none to stay none")
+	}
+}
+
+func TestToTry(t *testing.T) {
+	if value, err := option.Some(1).ToTry().ToOption(); err != nil || value != 1 {
+		t.Fatalf("expected a success of 1, got %v %v", value, err)
+	}
+	if res := option.None[int]().ToTry(); !errors.Is(res.Err(), option.ErrNone) {
+		t.Fatalf("expected ErrNone, got %v", res.Err())
+	}
+}
+
+func TestFromTry(t *testing.T) {
+	if value, ok := option.FromTry(try.Success(1)).Get(); !ok || value != 1 {
+		t.Fatalf("expected some 1, got %v %v", value, ok)
+	}
+	if option.FromTry(try.Failure[int](errors.New("down"))).IsSome() {
+		t.Fatal("expected none from a failure")
+	}
+
+	// A round trip keeps the value
+	if value, ok := option.FromTry(option.Some("a").ToTry()).Get(); !ok || value != "a" {
+		t.Fatalf("expected some \"a\", got %v %v", value, ok)
+	}
+}
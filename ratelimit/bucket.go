@@ -0,0 +1,73 @@
+//
+//  bucket.go
+//  ratelimit
+//
+//  Created by d-exclaimation on 4:31 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package ratelimit
+
+import "time"
+
+// tokenBucket is a policy that refills a token every interval up to the burst, where each permit takes a token
+type tokenBucket struct {
+	// every is the interval to refill a token
+	every time.Duration
+
+	// burst is the maximum number of tokens
+	burst float64
+
+	// tokens are the available tokens, negative for reserved future permits
+	tokens float64
+
+	// last is the time tokens were last refilled
+	last time.Time
+}
+
+// NewTokenBucket creates a Limiter that allows a burst of permits and refills one permit every interval
+func NewTokenBucket(every time.Duration, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return newLimiter(&tokenBucket{every: every, burst: float64(burst), tokens: float64(burst)})
+}
+
+// refill adds the tokens accumulated since the last refill
+func (b *tokenBucket) refill(now time.Time) {
+	if b.last.IsZero() {
+		b.last = now
+		return
+	}
+	if !now.After(b.last) {
+		return
+	}
+	if b.every <= 0 {
+		b.tokens = b.burst
+	} else {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.every)
+	}
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+func (b *tokenBucket) next(now time.Time) time.Time {
+	b.refill(now)
+	if b.tokens >= 1 {
+		return now
+	}
+	return now.Add(time.Duration((1 - b.tokens) * float64(b.every)))
+}
+
+func (b *tokenBucket) take(time.Time) {
+	b.tokens--
+}
+
+func (b *tokenBucket) refund(time.Time) {
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
@@ -0,0 +1,106 @@
+//
+//  limiter.go
+//  ratelimit
+//
+//  Created by d-exclaimation on 4:05 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package ratelimit
+
+import (
+	"context"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/task"
+	"sync"
+	"time"
+)
+
+// policy decides when permits are granted
+type policy interface {
+	// next return the earliest time the next permit can be granted
+	next(now time.Time) time.Time
+
+	// take grants the permit at the time
+	take(at time.Time)
+
+	// refund returns the permit granted at the time that was not used
+	refund(at time.Time)
+}
+
+// Limiter grants permits at a limited rate, where each permit is a Task that settles with the time it was granted.
+//
+//  limiter := ratelimit.NewTokenBucket(100*time.Millisecond, 5)
+//  for _, req := range requests {
+//      if _, err := limiter.Wait(ctx).Await(); err != nil {
+//          return err
+//      }
+//      send(req)
+//  }
+type Limiter struct {
+	// policy decides when permits are granted
+	policy policy
+
+	// mutex guards the policy
+	mutex sync.Mutex
+
+	// clock is the source of time captured from clock.Default
+	clock clock.Clock
+}
+
+// newLimiter creates a Limiter with the policy and the default clock
+func newLimiter(p policy) *Limiter {
+	return &Limiter{policy: p, clock: clock.Default()}
+}
+
+// Allow takes a permit only if one is available now
+func (l *Limiter) Allow() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := l.clock.Now()
+	if l.policy.next(now).After(now) {
+		return false
+	}
+	l.policy.take(now)
+	return true
+}
+
+// Reserve takes the next permit even if it is in the future, and return a Task that settles once it is granted
+func (l *Limiter) Reserve() *task.Task[time.Time] {
+	at, delay := l.reserve()
+	t := task.New[time.Time](func() (time.Time, error) {
+		<-l.clock.After(delay)
+		return at, nil
+	}).Named("Reserve")
+	t.Run()
+	return t
+}
+
+// Wait takes the next permit and return a Task that settles once it is granted,
+// or fails with the context's error and returns the permit if the context finished first
+func (l *Limiter) Wait(ctx context.Context) *task.Task[time.Time] {
+	at, delay := l.reserve()
+	t := task.New[time.Time](func() (time.Time, error) {
+		select {
+		case <-l.clock.After(delay):
+			return at, nil
+		case <-ctx.Done():
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+			l.policy.refund(at)
+			return time.Time{}, ctx.Err()
+		}
+	}).Named("Wait")
+	t.Run()
+	return t
+}
+
+// reserve takes the next permit and return when it is granted and how long until then
+func (l *Limiter) reserve() (time.Time, time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := l.clock.Now()
+	at := l.policy.next(now)
+	l.policy.take(at)
+	return at, at.Sub(now)
+}
@@ -0,0 +1,119 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+	"github.com/d-exclaimation/gocurrent/ratelimit"
+)
+
+// allowed counts how many permits Allow grants now, up to the limit
+func allowed(l *ratelimit.Limiter, limit int) int {
+	count := 0
+	for count < limit && l.Allow() {
+		count++
+	}
+	return count
+}
+
+func TestTokenBucket(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := ratelimit.NewTokenBucket(time.Second, 3)
+
+	if n := allowed(l, 10); n != 3 {
+		t.Fatalf("expected the burst of 3, got %d", n)
+	}
+	vc.Advance(time.Second)
+	if n := allowed(l, 10); n != 1 {
+		t.Fatalf("expected 1 refilled permit, got %d", n)
+	}
+	vc.Advance(10 * time.Second)
+	if n := allowed(l, 10); n != 3 {
+		t.Fatalf("expected the refill capped at the burst, got %d", n)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := ratelimit.NewSlidingWindow(2, time.Second)
+
+	if n := allowed(l, 10); n != 2 {
+		t.Fatalf("expected the limit of 2, got %d", n)
+	}
+	vc.Advance(500 * time.Millisecond)
+	if n := allowed(l, 10); n != 0 {
+		t.Fatalf("expected no permit within the window, got %d", n)
+	}
+	vc.Advance(500 * time.Millisecond)
+	if n := allowed(l, 10); n != 2 {
+		t.Fatalf("expected the window to slide, got %d", n)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	start := time.Unix(0, 0)
+	vc := clocktest.NewVirtual(start)
+	defer clock.SetDefault(vc)()
+	l := ratelimit.NewTokenBucket(time.Second, 1)
+
+	now := l.Reserve()
+	if at, err := now.Await(); !at.Equal(start) || err != nil {
+		t.Fatalf("expected an immediate permit, got %v %v", at, err)
+	}
+
+	later := l.Reserve()
+	vc.BlockUntil(1)
+	vc.Advance(999 * time.Millisecond)
+	if _, ok := later.Poll(); ok {
+		t.Fatal("expected the permit to wait for the refill")
+	}
+	vc.Advance(time.Millisecond)
+	if at, err := later.Await(); !at.Equal(start.Add(time.Second)) || err != nil {
+		t.Fatalf("expected the permit after a second, got %v %v", at, err)
+	}
+}
+
+func TestWaitCancelRefunds(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := ratelimit.NewTokenBucket(time.Second, 1)
+	_ = l.Allow()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wait := l.Wait(ctx)
+	vc.BlockUntil(1)
+	cancel()
+	if _, err := wait.Await(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	// The refunded permit is available again once refilled, instead of being owed to the cancelled waiter
+	vc.Advance(time.Second)
+	if !l.Allow() {
+		t.Fatal("expected the cancelled permit to be refunded")
+	}
+}
+
+func TestSlidingWindowWaitCancelRefunds(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := ratelimit.NewSlidingWindow(1, time.Second)
+	_ = l.Allow()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wait := l.Wait(ctx)
+	vc.BlockUntil(1)
+	cancel()
+	_, _ = wait.Await()
+
+	vc.Advance(time.Second)
+	if n := allowed(l, 10); n != 1 {
+		t.Fatalf("expected the window to be free of the cancelled permit, got %d", n)
+	}
+}
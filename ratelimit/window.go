@@ -0,0 +1,62 @@
+//
+//  window.go
+//  ratelimit
+//
+//  Created by d-exclaimation on 4:48 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package ratelimit
+
+import "time"
+
+// slidingWindow is a policy that grants at most the limit of permits within any window of time
+type slidingWindow struct {
+	// limit is the maximum permits within a window
+	limit int
+
+	// window is the length of the window
+	window time.Duration
+
+	// granted are the times of permits within the latest window in ascending order, including reserved ones
+	granted []time.Time
+}
+
+// NewSlidingWindow creates a Limiter that allows at most the limit of permits within any window of time
+func NewSlidingWindow(limit int, window time.Duration) *Limiter {
+	if limit < 1 {
+		limit = 1
+	}
+	return newLimiter(&slidingWindow{limit: limit, window: window})
+}
+
+func (w *slidingWindow) next(now time.Time) time.Time {
+	// Forget permits that left the window
+	expired := 0
+	for expired < len(w.granted) && !w.granted[expired].After(now.Add(-w.window)) {
+		expired++
+	}
+	w.granted = w.granted[expired:]
+
+	if len(w.granted) < w.limit {
+		return now
+	}
+	at := w.granted[len(w.granted)-w.limit].Add(w.window)
+	if at.Before(now) {
+		return now
+	}
+	return at
+}
+
+func (w *slidingWindow) take(at time.Time) {
+	w.granted = append(w.granted, at)
+}
+
+func (w *slidingWindow) refund(at time.Time) {
+	for i := len(w.granted) - 1; i >= 0; i-- {
+		if w.granted[i].Equal(at) {
+			w.granted = append(w.granted[:i], w.granted[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,141 @@
+//
+//  sema.go
+//  sema
+//
+//  Created by d-exclaimation on 6:02 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package sema
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"github.com/d-exclaimation/gocurrent/task"
+	"sync"
+)
+
+// ErrWeight is the error when acquiring more than the size of the semaphore
+var ErrWeight = errors.New("sema: Weight is larger than the semaphore")
+
+// Weighted is a semaphore where each acquisition takes a weight, granted to waiters in order.
+//
+//  s := sema.New(10)
+//  if _, err := s.Acquire(ctx, 3).Await(); err != nil {
+//      return err
+//  }
+//  defer s.Release(3)
+type Weighted struct {
+	// size is the total weight available
+	size int64
+
+	// current is the weight held
+	current int64
+
+	// waiters are the pending acquisitions in order
+	waiters list.List
+
+	// mutex guards the current weight and waiters
+	mutex sync.Mutex
+}
+
+// waiter is a pending acquisition
+type waiter struct {
+	n     int64
+	reply *task.Promise[struct{}]
+}
+
+// New instantiate a new Weighted semaphore with the total weight
+func New(size int64) *Weighted {
+	return &Weighted{size: size}
+}
+
+// Acquire return a Task that settles once the weight is held, or fails with the context's error if it finished first
+// in which case nothing is held.
+//
+// A waiting acquisition only holds a goroutine if the context can finish.
+func (s *Weighted) Acquire(ctx context.Context, n int64) *task.Task[struct{}] {
+	reply := task.Maybe[struct{}]()
+
+	s.mutex.Lock()
+	if n > s.size {
+		s.mutex.Unlock()
+		_ = reply.Failure(ErrWeight)
+		return reply.Task()
+	}
+	if s.size-s.current >= n && s.waiters.Len() == 0 {
+		s.current += n
+		s.mutex.Unlock()
+		_ = reply.Success(struct{}{})
+		return reply.Task()
+	}
+	element := s.waiters.PushBack(waiter{n: n, reply: reply})
+	s.mutex.Unlock()
+
+	if ctx.Done() != nil {
+		go s.watch(ctx, element, reply.Task())
+	}
+	return reply.Task()
+}
+
+// watch removes the pending acquisition if the context finished before it is granted
+func (s *Weighted) watch(ctx context.Context, element *list.Element, granted *task.Task[struct{}]) {
+	select {
+	case <-granted.Done():
+		return
+	case <-ctx.Done():
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	w := element.Value.(waiter)
+	if !w.reply.TryFailure(ctx.Err()) {
+		// Granted right as the context finished, which stays held
+		return
+	}
+	front := s.waiters.Front() == element
+	s.waiters.Remove(element)
+	if front && s.size > s.current {
+		s.notify()
+	}
+}
+
+// TryAcquire holds the weight only if available now without waiting
+func (s *Weighted) TryAcquire(n int64) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.size-s.current < n || s.waiters.Len() > 0 {
+		return false
+	}
+	s.current += n
+	return true
+}
+
+// Release gives back the weight and grants waiters in order
+func (s *Weighted) Release(n int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.current -= n
+	if s.current < 0 {
+		panic("sema: Released more than held")
+	}
+	s.notify()
+}
+
+// notify grants waiters in order while their weight fits (must hold the mutex)
+func (s *Weighted) notify() {
+	for {
+		next := s.waiters.Front()
+		if next == nil {
+			return
+		}
+		w := next.Value.(waiter)
+		if s.size-s.current < w.n {
+			return
+		}
+		s.current += w.n
+		s.waiters.Remove(next)
+		_ = w.reply.Success(struct{}{})
+	}
+}
@@ -0,0 +1,73 @@
+package sema_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/sema"
+)
+
+func TestAcquireOrder(t *testing.T) {
+	s := sema.New(3)
+	if _, err := s.Acquire(context.Background(), 3).Await(); err != nil {
+		t.Fatal(err)
+	}
+
+	large := s.Acquire(context.Background(), 2)
+	small := s.Acquire(context.Background(), 1)
+	if s.TryAcquire(1) {
+		t.Fatal("expected TryAcquire to respect the waiters")
+	}
+
+	s.Release(1)
+	select {
+	case <-small.Done():
+		t.Fatal("expected the smaller waiter to wait behind the larger one")
+	default:
+	}
+	s.Release(1)
+	if _, err := large.Await(); err != nil {
+		t.Fatal(err)
+	}
+	s.Release(1)
+	if _, err := small.Await(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAcquireCancelled(t *testing.T) {
+	s := sema.New(2)
+	_, _ = s.Acquire(context.Background(), 2).Await()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blocked := s.Acquire(ctx, 2)
+	next := s.Acquire(context.Background(), 1)
+	cancel()
+	if _, err := blocked.Await(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	s.Release(1)
+	select {
+	case <-next.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the waiter behind the cancelled one to be granted")
+	}
+}
+
+func TestAcquireTooLarge(t *testing.T) {
+	if _, err := sema.New(1).Acquire(context.Background(), 2).Await(); !errors.Is(err, sema.ErrWeight) {
+		t.Fatalf("expected ErrWeight, got %v", err)
+	}
+}
+
+func TestReleaseTooMuch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	sema.New(1).Release(1)
+}
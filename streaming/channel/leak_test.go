@@ -0,0 +1,70 @@
+package channel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/leakcheck"
+	"github.com/d-exclaimation/gocurrent/streaming/channel"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+func identity(v Any) Any {
+	return v
+}
+
+func TestMapLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	ch := make(chan Any)
+	out := channel.Map(ch, func(v interface{}) interface{} { return v })
+	close(ch)
+	for range out {
+	}
+}
+
+func TestApplyContextLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	ctx, cancel := context.WithCancel(context.Background())
+	out := channel.ApplyContext(make(chan Any), ctx)
+	cancel()
+	for range out {
+	}
+}
+
+func TestParallelMapLeak(t *testing.T) {
+	for name, operator := range map[string]func(context.Context, chan Any) <-chan Any{
+		"ParallelMap": func(ctx context.Context, ch chan Any) <-chan Any {
+			return channel.ParallelMap(ctx, ch, 4, identity)
+		},
+		"ParallelMapUnordered": func(ctx context.Context, ch chan Any) <-chan Any {
+			return channel.ParallelMapUnordered(ctx, ch, 4, identity)
+		},
+	} {
+		operator := operator
+		t.Run(name+" closed", func(t *testing.T) {
+			defer leakcheck.Check(t)()
+			ch := make(chan Any)
+			out := operator(context.Background(), ch)
+			go func() {
+				for i := 0; i < 10; i++ {
+					ch <- i
+				}
+				close(ch)
+			}()
+			for range out {
+			}
+		})
+		t.Run(name+" cancelled", func(t *testing.T) {
+			defer leakcheck.Check(t)()
+			ctx, cancel := context.WithCancel(context.Background())
+			ch := make(chan Any, 10)
+			for i := 0; i < 10; i++ {
+				ch <- i
+			}
+
+			// Cancelled without anyone reading the output
+			_ = operator(ctx, ch)
+			cancel()
+		})
+	}
+}
@@ -14,10 +14,11 @@ import (
 	. "github.com/d-exclaimation/gocurrent/types"
 )
 
-// Map adds a pipeline function on to the channel result
+// Map adds a pipeline function on to the channel result, which closes once the channel is closed
 func Map(ch streaming.Consumer, mapper func(interface{}) interface{}) streaming.Consumer {
 	channel := make(chan Any)
 	go func() {
+		defer close(channel)
 		for incoming := range ch {
 			channel <- mapper(incoming)
 		}
@@ -26,34 +27,29 @@ func Map(ch streaming.Consumer, mapper func(interface{}) interface{}) streaming.
 }
 
 // ApplyContext applies all the necessary setup with the context for closing and receiving data in channel
+//
+// The result closes once either the channel is closed or the context finished.
 func ApplyContext(ch streaming.Consumer, ctx context.Context) streaming.Consumer {
 	outgoing := make(chan Any)
-	bridge := make(chan interface{})
-	acid := make(chan struct{})
-
-	go func() {
-		<-ctx.Done()
-		acid <- struct{}{}
-	}()
 
 	go func() {
+		defer close(outgoing)
 		for {
 			select {
-			case incoming := <-bridge:
-				outgoing <- incoming
-			case _ = <-acid:
-				close(outgoing)
+			case incoming, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case outgoing <- incoming:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
 				return
 			}
 		}
 	}()
 
-	go func() {
-		for i := range ch {
-			bridge <- i
-		}
-		acid <- struct{}{}
-	}()
-
 	return outgoing
 }
@@ -0,0 +1,140 @@
+//
+//  parallel.go
+//  channel
+//
+//  Created by d-exclaimation on 3:12 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package channel
+
+import (
+	"context"
+	"github.com/d-exclaimation/gocurrent/streaming"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"sync"
+)
+
+// job is a single unit of work for the parallel workers with its own result slot
+type job struct {
+	data Any
+	slot chan Any
+}
+
+// ParallelMap adds a pipeline function on to the channel result that run concurrently with the given workers
+// and emits the results in the same order as the incoming channel.
+//
+// The reorder buffer is bounded by the number of workers, so memory stays capped regardless of how slow the mapper is.
+// The result closes once either the channel is closed or the context finished.
+func ParallelMap(ctx context.Context, ch streaming.Consumer, workers int, mapper func(Any) Any) streaming.Consumer {
+	if workers < 1 {
+		workers = 1
+	}
+
+	outgoing := make(chan Any)
+	pending := make(chan chan Any, workers)
+	jobs := make(chan job)
+
+	// Workers pool
+	for i := 0; i < workers; i++ {
+		go func() {
+			for curr := range jobs {
+				curr.slot <- mapper(curr.data)
+			}
+		}()
+	}
+
+	// Dispatcher, which reserve a slot in order before handing the data to a worker
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		for {
+			var incoming Any
+			select {
+			case data, ok := <-ch:
+				if !ok {
+					return
+				}
+				incoming = data
+			case <-ctx.Done():
+				return
+			}
+			slot := make(chan Any, 1)
+			select {
+			case pending <- slot:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job{data: incoming, slot: slot}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Collector, which wait for each slot in order
+	go func() {
+		defer close(outgoing)
+		for slot := range pending {
+			var res Any
+			select {
+			case res = <-slot:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case outgoing <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return outgoing
+}
+
+// ParallelMapUnordered adds a pipeline function on to the channel result that run concurrently with the given workers
+// and emits the results as soon as they are available.
+//
+// The result closes once either the channel is closed or the context finished.
+func ParallelMapUnordered(ctx context.Context, ch streaming.Consumer, workers int, mapper func(Any) Any) streaming.Consumer {
+	if workers < 1 {
+		workers = 1
+	}
+
+	outgoing := make(chan Any)
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				var incoming Any
+				select {
+				case data, ok := <-ch:
+					if !ok {
+						return
+					}
+					incoming = data
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case outgoing <- mapper(incoming):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	// Close once all workers finished
+	go func() {
+		wg.Wait()
+		close(outgoing)
+	}()
+
+	return outgoing
+}
@@ -0,0 +1,85 @@
+package channel_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/streaming/channel"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+// blocking return a mapper for which the first value waits until every other value of the batch is mapped,
+// so finishing out of order is guaranteed with enough workers
+func blocking(batch int) func(Any) Any {
+	var wg sync.WaitGroup
+	wg.Add(batch - 1)
+	return func(v Any) Any {
+		if v == 0 {
+			wg.Wait()
+		} else if v.(int) < batch {
+			wg.Done()
+		}
+		return v.(int) * 10
+	}
+}
+
+// feed return a channel that emits 0 until n then closes
+func feed(n int) chan Any {
+	ch := make(chan Any)
+	go func() {
+		defer close(ch)
+		for i := 0; i < n; i++ {
+			ch <- i
+		}
+	}()
+	return ch
+}
+
+func TestParallelMapOrder(t *testing.T) {
+	out := channel.ParallelMap(context.Background(), feed(20), 4, blocking(4))
+
+	i := 0
+	for res := range out {
+		if res != i*10 {
+			t.Fatalf("expected %d at %d, got %v", i*10, i, res)
+		}
+		i++
+	}
+	if i != 20 {
+		t.Fatalf("expected 20 results, got %d", i)
+	}
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	out := channel.ParallelMapUnordered(context.Background(), feed(20), 4, blocking(4))
+
+	var res []int
+	for v := range out {
+		res = append(res, v.(int))
+	}
+	if res[0] == 0 {
+		t.Fatalf("expected the blocked value not to come first, got %v", res)
+	}
+	sort.Ints(res)
+	for i, v := range res {
+		if v != i*10 {
+			t.Fatalf("expected every value once, got %v", res)
+		}
+	}
+}
+
+func TestParallelMapSingleWorker(t *testing.T) {
+	out := channel.ParallelMap(context.Background(), feed(5), 0, func(v Any) Any {
+		return v
+	})
+
+	i := 0
+	for res := range out {
+		if res != i {
+			t.Fatalf("expected %d, got %v", i, res)
+		}
+		i++
+	}
+}
@@ -10,38 +10,27 @@ package jet
 
 import (
 	"github.com/d-exclaimation/gocurrent/task"
+	"github.com/d-exclaimation/gocurrent/try"
 	. "github.com/d-exclaimation/gocurrent/types"
-	"time"
 )
 
 // From instantiate a new Jet stream from a channel
+//
+// The Jet closes once the channel is closed, and stop reading from the channel once the Jet is closed.
 func From(ch <-chan Any, opts ...Option) *Jet {
 	jt := New(opts...)
-	bridge := make(chan Any)
-	acid := make(chan struct{})
 
-	// End signal from channel
-	go func() {
-		for incoming := range ch {
-			bridge <- incoming
-		}
-		acid <- struct{}{}
-	}()
-
-	// End signal from Jet
-	go func() {
-		<-jt.Done()
-		acid <- struct{}{}
-	}()
-
-	// Main iteration go routine
+	// Main iteration go routine, ending with either the channel or the Jet
 	go func() {
 		for {
 			select {
-			case incoming := <-bridge:
+			case incoming, ok := <-ch:
+				if !ok {
+					jt.Close()
+					return
+				}
 				jt.Up(incoming)
-			case _ = <-acid:
-				jt.Close()
+			case <-jt.Done():
 				return
 			}
 		}
@@ -57,26 +46,31 @@ func Empty() *Jet {
 	return jt
 }
 
-// Future instantiate a Jet stream with a value after future completed and closes
+// Future instantiate a Jet stream with a value after future completed and the first consumer registered, and closes
+//
+// The Jet waits for its first consumer so the value is never lost, so Close it if it may never be subscribed to.
 func Future(fut *task.Task[Any], opts ...Option) *Jet {
 	jt := New(opts...)
-	defer routine(func() {
-		data, err := fut.Await()
-		if err == nil {
-			jt.Up(data)
-		}
-		defer jt.Close()
-	})
+	go jt.forward(fut)
 	return jt
 }
 
-// routine allocate function late-ly to a new goroutine
-func routine(fn func()) {
-	go late(fn)
-}
-
-// late call a function lately
-func late(fn func()) {
-	time.Sleep(10 * time.Millisecond)
-	fn()
+// forward pushes the value of the future once there is a consumer to receive it, and closes,
+// or stops early if the Jet is closed
+func (j *Jet) forward(fut *task.Task[Any]) {
+	var res *try.Try[Any]
+	select {
+	case res = <-fut.AwaitChannel():
+	case <-j.closed:
+		return
+	}
+	data, err := res.ToOption()
+	if err == nil {
+		select {
+		case <-j.subscribed:
+			j.Up(data)
+		case <-j.closed:
+		}
+	}
+	j.Close()
 }
@@ -11,11 +11,18 @@ package jet
 import (
 	"context"
 	"errors"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/observe"
+	"github.com/d-exclaimation/gocurrent/option"
 	"github.com/d-exclaimation/gocurrent/streaming"
 	. "github.com/d-exclaimation/gocurrent/types"
 	"log"
+	"sync"
 )
 
+// errFinished is the error when detaching from a Jet that finished
+var errFinished = errors.New("jet 'Unlink': Jet has finished or been shutdown forcefully")
+
 // Jet is a data structure for streaming like behavior with a singular upstream and multiple consumer channel.
 //
 //  jt := jet.New()
@@ -34,7 +41,7 @@ import (
 //
 //  jt := jet.New()
 //  for jt.Next() {
-//      log.Println(jt.Value())
+//      log.Println(jt.Value().OrElse(nil))
 //  }
 //
 // Also handle single recent value request with caching and provide method like Await and AwaitNoCache.
@@ -49,14 +56,20 @@ type Jet struct {
 	// unregistrar is the channel to concurrently unset and close a consumer channel
 	unregistrar chan streaming.Consumer
 
+	// registryMutex guards sending on the buffered registrar and unregistrar against draining them on shutdown
+	registryMutex sync.RWMutex
+
 	// awaiter is the channel for sending single use channel
-	awaiter chan chan Any
+	awaiter chan chan option.Option[Any]
 
 	// acid is the shutdown channel
 	acid chan Signal
 
-	// latestSnapshot is the preserved latest value
-	latestSnapshot Any
+	// latestSnapshot is the preserved latest value, none before the first emit
+	latestSnapshot option.Option[Any]
+
+	// snapshotMutex guards the latestSnapshot read outside the actor
+	snapshotMutex sync.RWMutex
 
 	// accumulatedError is the accumulated errors
 	accumulatedError error
@@ -64,14 +77,34 @@ type Jet struct {
 	// downstream is the map state for store long-running consumer to producer channel pair
 	downstream streaming.Downstreams
 
-	// waiters is the map state for store single use channel
-	waiters streaming.Downstreams
+	// waiters is the set state for store single use channel
+	waiters map[chan option.Option[Any]]Signal
+
+	// closed is closed once the Jet finished, right before all downstream are closed
+	closed chan Signal
+
+	// closing guards sending the shutdown signal only once
+	closing sync.Once
+
+	// subscribed is closed once the first consumer or waiter registered
+	subscribed chan Signal
+
+	// hasSubscriber is the state to indicate whether subscribed has been closed
+	hasSubscriber bool
 
-	// isDone is the state to indicate whether Jet finished
-	isDone bool
+	// clock is the source of time for time-based operators
+	clock clock.Clock
+
+	// observer is notified for metrics
+	observer observe.Observer
+
+	// name is the identifier for the observer
+	name string
 }
 
 // New instantiate a new Jet and run the behavior in a separate goroutine.
+//
+// The goroutine exits once the Jet is closed with Close or Fail.
 func New(opts ...Option) *Jet {
 	return Lazy(opts...)()
 }
@@ -98,6 +131,8 @@ func (j *Jet) receive() {
 				continue
 			}
 			j.downstream[channel] = channel
+			j.subscribe()
+			j.observer.SubscriberAttached(j.name, len(j.downstream))
 		case consumer, valid := <-j.unregistrar:
 			if !valid {
 				continue
@@ -106,6 +141,7 @@ func (j *Jet) receive() {
 			if ok {
 				close(producer)
 				delete(j.downstream, consumer)
+				j.observer.SubscriberDetached(j.name, len(j.downstream))
 			}
 
 		// Single value consumer
@@ -113,67 +149,134 @@ func (j *Jet) receive() {
 			if !valid {
 				continue
 			}
-			j.waiters[await] = await
+			j.waiters[await] = Signal{}
+			j.subscribe()
 
 		case _, valid := <-j.acid:
 			if !valid {
 				continue
 			}
-			j.isDone = true
+			j.drain()
 			j.shutdown()
 			return
 		}
 	}
 }
 
+// subscribe marks the Jet as having its first consumer
+func (j *Jet) subscribe() {
+	if j.hasSubscriber {
+		return
+	}
+	j.hasSubscriber = true
+	close(j.subscribed)
+}
+
+// drain emits all values still buffered in the upstream, so values pushed before Close are never lost
+func (j *Jet) drain() {
+	for {
+		select {
+		case snapshot := <-j.upstream:
+			j.emit(snapshot)
+		default:
+			return
+		}
+	}
+}
+
 // emit dispatch all the element to all downstream and waiters
 func (j *Jet) emit(snapshot Any) {
-	j.latestSnapshot = snapshot
+	j.observer.JetEmitted(j.name, len(j.downstream)+len(j.waiters))
+	j.observer.BufferOccupancy(j.name, len(j.upstream), cap(j.upstream))
+	j.snapshotMutex.Lock()
+	j.latestSnapshot = option.Some(snapshot)
+	j.snapshotMutex.Unlock()
 	for _, producer := range j.downstream {
 		producer <- snapshot
 	}
-	for awaitConsumer, awaitProducer := range j.waiters {
-		awaitProducer <- snapshot
-		close(awaitProducer)
-		delete(j.waiters, awaitConsumer)
+	for await := range j.waiters {
+		await <- option.Some(snapshot)
+		close(await)
+		delete(j.waiters, await)
 	}
 }
 
 // shutdown close all downstream, waiters, and channels
 func (j *Jet) shutdown() {
+	close(j.closed)
+	j.unregister()
 	for consumer, producer := range j.downstream {
 		close(producer)
 		delete(j.downstream, consumer)
+		j.observer.SubscriberDetached(j.name, len(j.downstream))
+	}
+	for await := range j.waiters {
+		await <- option.None[Any]()
+		close(await)
+		delete(j.waiters, await)
+	}
+}
+
+// unregister closes every consumer still buffered in the registrar and discards the unregistrar,
+// once no Sink or Detach can send anymore
+func (j *Jet) unregister() {
+	j.registryMutex.Lock()
+	defer j.registryMutex.Unlock()
+	for {
+		select {
+		case channel := <-j.registrar:
+			close(channel)
+		case <-j.unregistrar:
+		default:
+			return
+		}
 	}
-	for awaitConsumer, awaitProducer := range j.waiters {
-		awaitProducer <- j.latestSnapshot
-		close(awaitProducer)
-		delete(j.waiters, awaitConsumer)
+}
+
+// isDone indicates whether the Jet finished
+func (j *Jet) isDone() bool {
+	select {
+	case <-j.closed:
+		return true
+	default:
+		return false
 	}
-	close(j.awaiter)
-	close(j.registrar)
-	close(j.unregistrar)
-	close(j.acid)
-	close(j.upstream)
 }
 
-// Up pushes a new value into the Jet
+// Up pushes a new value into the Jet, ignored if the Jet finished
 func (j *Jet) Up(data Any) {
-	if j.isDone {
+	if j.isDone() {
+		j.observer.Dropped(j.name, 1)
 		return
 	}
 
-	j.upstream <- data
+	select {
+	case j.upstream <- data:
+	case <-j.closed:
+		j.observer.Dropped(j.name, 1)
+	}
 }
 
-// Close shutdown the entire Jet and all downstream from Sink
+// Close shutdown the entire Jet and all downstream from Sink after every value already pushed is emitted
 func (j *Jet) Close() {
-	if j.isDone {
-		return
-	}
+	j.closeWith(nil)
+}
+
+// Fail shutdown the entire Jet like Close but with an error reported by Err, ignored if already closing
+func (j *Jet) Fail(err error) {
+	j.closeWith(err)
+}
 
-	go late(func() {
-		j.acid <- Signal{}
+// closeWith sends the shutdown signal once with the error if any
+func (j *Jet) closeWith(err error) {
+	j.closing.Do(func() {
+		j.accumulatedError = err
+		go func() {
+			select {
+			case j.acid <- Signal{}:
+			case <-j.closed:
+			}
+		}()
 	})
 }
 
@@ -181,10 +284,16 @@ func (j *Jet) Close() {
 func (j *Jet) Sink() streaming.Consumer {
 	consumer := make(chan Any)
 
-	if j.isDone {
-		defer close(consumer)
-	} else {
-		j.registrar <- consumer
+	j.registryMutex.RLock()
+	defer j.registryMutex.RUnlock()
+	if j.isDone() {
+		close(consumer)
+		return consumer
+	}
+	select {
+	case j.registrar <- consumer:
+	case <-j.closed:
+		close(consumer)
 	}
 
 	return consumer
@@ -192,19 +301,28 @@ func (j *Jet) Sink() streaming.Consumer {
 
 // Detach unregisters a consumer channel and return an error
 func (j *Jet) Detach(ch streaming.Consumer) error {
-	if j.isDone {
-		return errors.New("jet 'Unlink': Jet has finished or been shutdown forcefully")
+	j.registryMutex.RLock()
+	defer j.registryMutex.RUnlock()
+	if j.isDone() {
+		return errFinished
+	}
+	select {
+	case j.unregistrar <- ch:
+		return nil
+	case <-j.closed:
+		return errFinished
 	}
-	j.unregistrar <- ch
-	return nil
 }
 
-// Snapshots register a consumer channel and unregister on finished context
+// Snapshots register a consumer channel and unregister on finished context, or once the Jet finished
 func (j *Jet) Snapshots(ctx context.Context) <-chan Any {
 	sink := j.Sink()
 	go func() {
-		<-ctx.Done()
-		_ = j.Detach(sink)
+		select {
+		case <-ctx.Done():
+			_ = j.Detach(sink)
+		case <-j.closed:
+		}
 	}()
 	return sink
 }
@@ -218,7 +336,7 @@ func (j *Jet) OnSnapshot(ctx context.Context, callback func(snapshot Any)) <-cha
 		for snapshot := range ch {
 			callback(snapshot)
 		}
-		done <- Signal{}
+		close(done)
 	}()
 
 	return done
@@ -233,7 +351,7 @@ func (j *Jet) On(callback func(Any)) (<-chan Signal, func()) {
 		for snapshot := range ch {
 			callback(snapshot)
 		}
-		done <- Signal{}
+		close(done)
 	}()
 
 	return done, func() {
@@ -243,52 +361,52 @@ func (j *Jet) On(callback func(Any)) (<-chan Signal, func()) {
 	}
 }
 
-// Await is method for waiting for the next value in the Jet otherwise use the latestSnapshot
+// Await is method for waiting for the next value in the Jet otherwise use the latestSnapshot (nil if there is none)
 func (j *Jet) Await() Any {
 	res := j.AwaitNoCache()
-	if res == nil {
-		return j.latestSnapshot
+	if res.IsNone() {
+		return j.Value().OrElse(nil)
 	}
-	return res
+	return res.OrElse(nil)
 }
 
-// AwaitNoCache is a method for waiting for the next value in the Jet but doesn't use the latestSnapshot
-func (j *Jet) AwaitNoCache() Any {
-	if j.isDone {
-		return nil
+// AwaitNoCache is a method for waiting for the next value in the Jet but doesn't use the latestSnapshot,
+// which is none if the Jet finished before any new value
+func (j *Jet) AwaitNoCache() option.Option[Any] {
+	await := make(chan option.Option[Any], 1)
+	select {
+	case j.awaiter <- await:
+		return <-await
+	case <-j.closed:
+		return option.None[Any]()
 	}
-
-	await := make(chan Any)
-	j.awaiter <- await
-	return <-await
 }
 
+// Done returns a channel that is closed once the Jet finished
 func (j *Jet) Done() <-chan Signal {
-	done := make(chan Signal)
-	go func() {
-		for !j.isDone {
-		}
-		done <- Signal{}
-	}()
-	return done
+	return j.closed
 }
 
 // --- Iterator ---
 
 // Next give back a boolean to indicate whether the iterator finished
 func (j *Jet) Next() bool {
-	res := j.AwaitNoCache()
-	return res != nil || !j.isDone
+	return j.AwaitNoCache().IsSome()
 }
 
-// Value return the current value in the iteration
+// Value return the current value in the iteration, none before the first value
 //
 // Note: To get next value, call Next method
-func (j *Jet) Value() Any {
+func (j *Jet) Value() option.Option[Any] {
+	j.snapshotMutex.RLock()
+	defer j.snapshotMutex.RUnlock()
 	return j.latestSnapshot
 }
 
-// Err return the accumulated error from the Jet iterator
+// Err return the accumulated error from the Jet iterator, or the error given to Fail once the Jet finished
 func (j *Jet) Err() error {
+	if !j.isDone() {
+		return nil
+	}
 	return j.accumulatedError
 }
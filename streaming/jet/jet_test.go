@@ -0,0 +1,125 @@
+package jet_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+func TestUpThenClose(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		jt := jet.New()
+		received := make(chan int)
+		go func(sink <-chan Any) {
+			count := 0
+			for snapshot := range sink {
+				if snapshot != count {
+					t.Errorf("expected %d, got %v", count, snapshot)
+				}
+				count++
+			}
+			received <- count
+		}(jt.Sink())
+		for j := 0; j < 10; j++ {
+			jt.Up(j)
+		}
+		jt.Close()
+
+		if count := <-received; count != 10 {
+			t.Fatalf("expected every value before Close, got %d", count)
+		}
+	}
+}
+
+func TestUpRacingClose(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		jt := jet.New()
+		sink := jt.Sink()
+		var wg sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				jt.Up(1)
+			}()
+		}
+		go func() {
+			wg.Wait()
+			jt.Close()
+		}()
+
+		count := 0
+		for range sink {
+			count++
+		}
+		if count != 4 {
+			t.Fatalf("expected every value pushed before Close, got %d", count)
+		}
+	}
+}
+
+func TestAwaitNoCacheAfterClose(t *testing.T) {
+	jt := jet.New()
+	jt.Close()
+	<-jt.Done()
+	if res := jt.AwaitNoCache(); res.IsSome() {
+		t.Fatalf("expected none from a finished Jet, got %v", res)
+	}
+}
+
+func TestSinkAfterCloseBuffered(t *testing.T) {
+	for _, opt := range []jet.Option{jet.WithBuffer(4), jet.WithDownstreamBuffer(4)} {
+		for i := 0; i < 200; i++ {
+			jt := jet.New(opt)
+			jt.Close()
+			<-jt.Done()
+			for range jt.Sink() {
+			}
+			if err := jt.Detach(jt.Sink()); err == nil {
+				t.Fatal("expected Detach to fail on a finished Jet")
+			}
+		}
+	}
+}
+
+func TestSinkRacingCloseBuffered(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		jt := jet.New(jet.WithBuffer(4))
+		var wg sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range jt.Sink() {
+				}
+			}()
+		}
+		jt.Close()
+		wg.Wait()
+	}
+}
+
+func TestValue(t *testing.T) {
+	jt := jet.New()
+	if res := jt.Value(); res.IsSome() {
+		t.Fatalf("expected none before any value, got %v", res)
+	}
+
+	// A nil value is still a value
+	sink := jt.Sink()
+	go jt.Up(nil)
+	<-sink
+	if res, ok := jt.Value().Get(); !ok || res != nil {
+		t.Fatalf("expected some nil, got %v", jt.Value())
+	}
+	jt.Close()
+
+	empty := jet.New()
+	empty.Close()
+	<-empty.Done()
+	if res := empty.Value(); res.IsSome() {
+		t.Fatalf("expected none from a Jet closed without values, got %v", res)
+	}
+}
@@ -0,0 +1,138 @@
+//
+//  marble.go
+//  jettest
+//
+//  Created by d-exclaimation on 3:04 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package jettest
+
+import (
+	"fmt"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"reflect"
+	"strings"
+)
+
+// kind is the type of event in a marble diagram
+type kind int
+
+const (
+	// next is a value pushed with Up
+	next kind = iota
+
+	// complete is the Jet closing with Close, marked as '|'
+	complete
+
+	// failure is the Jet closing with Fail, marked as '#'
+	failure
+)
+
+// event is a single notification at a frame
+type event struct {
+	frame int
+	kind  kind
+	value Any
+}
+
+// equal compares two events, where failures are equal regardless of the error
+func (e event) equal(other event) bool {
+	if e.frame != other.frame || e.kind != other.kind {
+		return false
+	}
+	return e.kind != next || reflect.DeepEqual(e.value, other.value)
+}
+
+// parse reads a marble diagram into events.
+//
+// Each '-' is one frame, any other character is a value (looked up in values, or the character itself as string),
+// '|' is completion, '#' is failure, and '(...)' groups events in the same frame. Spaces are ignored.
+func parse(marble string, values map[string]Any) []event {
+	var (
+		res     []event
+		frame   = 0
+		grouped = false
+	)
+	for _, ch := range marble {
+		curr := event{frame: frame}
+		switch ch {
+		case ' ':
+			continue
+		case '-':
+			frame++
+			continue
+		case '(':
+			grouped = true
+			continue
+		case ')':
+			grouped = false
+			frame++
+			continue
+		case '|':
+			curr.kind = complete
+		case '#':
+			curr.kind = failure
+		default:
+			curr.kind = next
+			curr.value = string(ch)
+			if value, ok := values[string(ch)]; ok {
+				curr.value = value
+			}
+		}
+		res = append(res, curr)
+		if !grouped {
+			frame++
+		}
+	}
+	return res
+}
+
+// render writes events into a marble diagram, the reverse of parse
+func render(events []event, values map[string]Any) string {
+	if len(events) == 0 {
+		return ""
+	}
+
+	frames := make(map[int][]string)
+	last := 0
+	for _, ev := range events {
+		frames[ev.frame] = append(frames[ev.frame], symbol(ev, values))
+		if ev.frame > last {
+			last = ev.frame
+		}
+	}
+
+	var builder strings.Builder
+	for frame := 0; frame <= last; frame++ {
+		symbols := frames[frame]
+		switch len(symbols) {
+		case 0:
+			builder.WriteString("-")
+		case 1:
+			builder.WriteString(symbols[0])
+		default:
+			builder.WriteString("(" + strings.Join(symbols, "") + ")")
+		}
+	}
+	return builder.String()
+}
+
+// symbol return the marble character for an event
+func symbol(ev event, values map[string]Any) string {
+	switch ev.kind {
+	case complete:
+		return "|"
+	case failure:
+		return "#"
+	}
+	for key, value := range values {
+		if reflect.DeepEqual(value, ev.value) {
+			return key
+		}
+	}
+	if str, ok := ev.value.(string); ok {
+		return str
+	}
+	return fmt.Sprintf("[%v]", ev.value)
+}
@@ -0,0 +1,198 @@
+//
+//  scheduler.go
+//  jettest
+//
+//  Created by d-exclaimation on 3:26 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package jettest
+
+import (
+	"errors"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ErrMarble is the error used for '#' in marble diagrams
+var ErrMarble = errors.New("jettest: Marble error")
+
+// expectation is an output Jet recorded against an expected marble
+type expectation struct {
+	marble string
+	values map[string]Any
+	mutex  sync.Mutex
+	events []event
+}
+
+// Scheduler plays and records Jets from marble diagrams on virtual time.
+//
+//  jettest.Run(t, func(s *jettest.Scheduler) {
+//      source := s.Hot("-a-b-(cd)-|", nil)
+//      s.Expect(jet.Map(source, upper), "-A-B-(CD)-|", nil)
+//  })
+//
+// Nothing is played until Flush, so every Jet can be subscribed to beforehand.
+type Scheduler struct {
+	// t is the test to report mismatches to
+	t testing.TB
+
+	// clock is the virtual time
+	clock *clocktest.Virtual
+
+	// frame is the duration of a single '-'
+	frame time.Duration
+
+	// base is the virtual time of frame zero
+	base time.Time
+
+	// started is closed once the Scheduler is flushed
+	started chan Signal
+
+	// expectations are the recorded output Jets
+	expectations []*expectation
+
+	// flushed is the state to indicate whether the Scheduler has been flushed
+	flushed bool
+}
+
+// Run creates a Scheduler with its virtual Clock as clock.Default, runs the test, and flush it.
+func Run(t testing.TB, test func(s *Scheduler)) {
+	t.Helper()
+	s := &Scheduler{
+		t:       t,
+		clock:   clocktest.NewVirtual(time.Unix(0, 0)),
+		frame:   time.Millisecond,
+		started: make(chan Signal),
+	}
+	s.base = s.clock.Now()
+	restore := clock.SetDefault(s.clock)
+	defer restore()
+
+	test(s)
+	s.Flush()
+}
+
+// Clock return the virtual Clock of the Scheduler
+func (s *Scheduler) Clock() *clocktest.Virtual {
+	return s.clock
+}
+
+// Frames return the duration of n frames, useful for time-based operators
+func (s *Scheduler) Frames(n int) time.Duration {
+	return time.Duration(n) * s.frame
+}
+
+// Hot creates a Jet that plays the marble from frame zero regardless of subscribers
+func (s *Scheduler) Hot(marble string, values map[string]Any) *jet.Jet {
+	jt := jet.New(jet.WithClock(s.clock))
+	go s.play(jt, parse(marble, values), s.base)
+	return jt
+}
+
+// Cold creates a RunnableJet that plays the marble from the frame it is run, for each run
+func (s *Scheduler) Cold(marble string, values map[string]Any) jet.RunnableJet {
+	events := parse(marble, values)
+	return func() *jet.Jet {
+		jt := jet.New(jet.WithClock(s.clock))
+		go s.play(jt, events, s.clock.Now())
+		return jt
+	}
+}
+
+// play pushes the events to the Jet on their frame relative to the offset once the Scheduler is flushed
+func (s *Scheduler) play(jt *jet.Jet, events []event, offset time.Time) {
+	<-s.started
+	for _, ev := range events {
+		at := offset.Add(s.Frames(ev.frame))
+		if wait := at.Sub(s.clock.Now()); wait > 0 {
+			s.clock.Sleep(wait)
+		}
+		switch ev.kind {
+		case next:
+			jt.Up(ev.value)
+		case complete:
+			jt.Close()
+			return
+		case failure:
+			jt.Fail(ErrMarble)
+			return
+		}
+	}
+}
+
+// Expect subscribes to the Jet now and asserts everything it emits against the marble once flushed
+func (s *Scheduler) Expect(jt *jet.Jet, marble string, values map[string]Any) {
+	exp := &expectation{
+		marble: marble,
+		values: values,
+	}
+	s.expectations = append(s.expectations, exp)
+
+	ch := jt.Sink()
+	go func() {
+		for snapshot := range ch {
+			exp.record(event{frame: s.frameOf(s.clock.Now()), kind: next, value: snapshot})
+		}
+		ev := event{frame: s.frameOf(s.clock.Now()), kind: complete}
+		if jt.Err() != nil {
+			ev.kind = failure
+		}
+		exp.record(ev)
+	}()
+}
+
+// frameOf return the frame of the virtual time
+func (s *Scheduler) frameOf(at time.Time) int {
+	return int(at.Sub(s.base) / s.frame)
+}
+
+// record appends an event
+func (e *expectation) record(ev event) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.events = append(e.events, ev)
+}
+
+// Flush plays every Jet until no timers are left and every gocurrent goroutine is blocked, and reports every mismatched
+// expectation
+func (s *Scheduler) Flush() {
+	s.t.Helper()
+	if s.flushed {
+		return
+	}
+	s.flushed = true
+	close(s.started)
+	s.clock.RunUntilIdle()
+
+	// Every gocurrent goroutine is blocked once idle, so every recorder already saw all it will ever see
+	for _, exp := range s.expectations {
+		expected := parse(exp.marble, exp.values)
+
+		exp.mutex.Lock()
+		actual := append([]event{}, exp.events...)
+		exp.mutex.Unlock()
+
+		if !matches(expected, actual) {
+			s.t.Errorf("jettest: Expected %q but got %q", render(expected, exp.values), render(actual, exp.values))
+		}
+	}
+}
+
+// matches compares two sequences of events
+func matches(expected, actual []event) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		if !expected[i].equal(actual[i]) {
+			return false
+		}
+	}
+	return true
+}
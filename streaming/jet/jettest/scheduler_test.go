@@ -0,0 +1,49 @@
+package jettest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/streaming/jet/jettest"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+func upper(v Any) Any {
+	return strings.ToUpper(v.(string))
+}
+
+func TestMap(t *testing.T) {
+	jettest.Run(t, func(s *jettest.Scheduler) {
+		source := s.Hot("-a-b-(cd)-|", nil)
+		s.Expect(jet.Map(source, upper), "-A-B-(CD)-|", nil)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	jettest.Run(t, func(s *jettest.Scheduler) {
+		source := s.Hot("-a-b-a-|", nil)
+		s.Expect(jet.Filter(source, func(v Any) bool { return v == "a" }), "-a---a-|", nil)
+	})
+}
+
+func TestFailure(t *testing.T) {
+	jettest.Run(t, func(s *jettest.Scheduler) {
+		source := s.Hot("-a-#", nil)
+		s.Expect(jet.Map(source, upper), "-A-#", nil)
+	})
+}
+
+func TestDebounce(t *testing.T) {
+	jettest.Run(t, func(s *jettest.Scheduler) {
+		source := s.Hot("-ab----c---|", nil)
+		s.Expect(jet.Debounce(source, s.Frames(3)), "-----b----c|", nil)
+	})
+}
+
+func TestCold(t *testing.T) {
+	jettest.Run(t, func(s *jettest.Scheduler) {
+		source := s.Cold("-a-b-|", nil)
+		s.Expect(source(), "-a-b-|", nil)
+	})
+}
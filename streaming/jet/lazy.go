@@ -9,6 +9,9 @@
 package jet
 
 import (
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/observe"
+	"github.com/d-exclaimation/gocurrent/option"
 	"github.com/d-exclaimation/gocurrent/streaming"
 	"github.com/d-exclaimation/gocurrent/task"
 	. "github.com/d-exclaimation/gocurrent/types"
@@ -23,6 +26,9 @@ func Lazy(opts ...Option) RunnableJet {
 		register   = make(chan chan Any)
 		unregister = make(chan streaming.Consumer)
 		acid       = make(chan Signal)
+		clk        = clock.Default()
+		observer   = observe.Default()
+		name       = ""
 	)
 
 	// Setup for optional fields and configuration
@@ -43,11 +49,17 @@ func Lazy(opts ...Option) RunnableJet {
 			register = make(chan chan Any, buffer)
 			unregister = make(chan streaming.Consumer, buffer)
 			acid = make(chan Signal, buffer)
-		default:
+		case notBuffered:
 			upstream = make(chan Any)
 			register = make(chan chan Any)
 			unregister = make(chan streaming.Consumer)
 			acid = make(chan Signal)
+		case clocked:
+			clk = opt.(clocked).Clock
+		case observed:
+			observer = opt.(observed).Observer
+		case named:
+			name = string(opt.(named))
 		}
 	}
 
@@ -55,10 +67,15 @@ func Lazy(opts ...Option) RunnableJet {
 		upstream:    upstream,
 		registrar:   register,
 		unregistrar: unregister,
-		awaiter:     make(chan chan Any),
+		awaiter:     make(chan chan option.Option[Any]),
 		acid:        acid,
 		downstream:  make(streaming.Downstreams),
-		waiters:     make(streaming.Downstreams),
+		waiters:     make(map[chan option.Option[Any]]Signal),
+		closed:      make(chan Signal),
+		subscribed:  make(chan Signal),
+		clock:       clk,
+		observer:    observer,
+		name:        name,
 	}
 	return func() *Jet {
 		jt.behavior()
@@ -67,17 +84,13 @@ func Lazy(opts ...Option) RunnableJet {
 }
 
 // LazyFuture setups a function to run a Jet stream with a value after future completed and closes
+//
+// Like Future, each Jet waits for its first consumer, so Close it if it may never be subscribed to.
 func LazyFuture(fut func() *task.Task[Any]) RunnableJet {
 	run := Lazy()
 	return func() *Jet {
 		jt := run()
-		go func() {
-			data, err := fut().Await()
-			if err == nil {
-				jt.Up(data)
-			}
-			jt.Close()
-		}()
+		go jt.forward(fut())
 		return jt
 	}
 }
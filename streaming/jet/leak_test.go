@@ -0,0 +1,138 @@
+package jet_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/leakcheck"
+	"github.com/d-exclaimation/gocurrent/ratelimit"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/task"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+// drain reads the Jet until it closes
+func drain(jt *jet.Jet) []Any {
+	var res []Any
+	for snapshot := range jt.Sink() {
+		res = append(res, snapshot)
+	}
+	return res
+}
+
+func identity(v Any) Any {
+	return v
+}
+
+func TestNewLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	jt := jet.New()
+	_, detach := jt.On(func(Any) {})
+	detach()
+	jt.Close()
+}
+
+func TestFromLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	ch := make(chan Any)
+	jt := jet.From(ch)
+	close(ch)
+	<-jt.Done()
+}
+
+func TestFromClosedLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+
+	// The channel is never closed, so the Jet is closed instead
+	jt := jet.From(make(chan Any))
+	jt.Close()
+}
+
+func TestEmptyLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	<-jet.Empty().Done()
+}
+
+func TestFutureLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	jt := jet.Future(task.Async(func() (Any, error) {
+		return 1, nil
+	}))
+	if res := drain(jt); len(res) != 1 || res[0] != 1 {
+		t.Fatalf("expected the future value, got %v", res)
+	}
+}
+
+func TestFutureUnsubscribedLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	jt := jet.Future(task.Async(func() (Any, error) {
+		return 1, nil
+	}))
+	jt.Close()
+}
+
+func TestFuturePendingLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	jt := jet.Future(task.Maybe[Any]().Task())
+	jt.Close()
+}
+
+func TestSnapshotsLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	jt := jet.New()
+	defer jt.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := jt.OnSnapshot(ctx, func(Any) {})
+	cancel()
+	<-done
+}
+
+func TestOperatorsLeak(t *testing.T) {
+	operators := map[string]func(*jet.Jet) *jet.Jet{
+		"Map": func(jt *jet.Jet) *jet.Jet {
+			return jet.Map(jt, identity)
+		},
+		"Filter": func(jt *jet.Jet) *jet.Jet {
+			return jet.Filter(jt, func(Any) bool { return true })
+		},
+		"FilterMap": func(jt *jet.Jet) *jet.Jet {
+			return jet.FilterMap(jt, func(v Any) (bool, Any) { return true, v })
+		},
+		"ParallelMap": func(jt *jet.Jet) *jet.Jet {
+			return jet.ParallelMap(jt, 4, identity)
+		},
+		"ParallelMapUnordered": func(jt *jet.Jet) *jet.Jet {
+			return jet.ParallelMapUnordered(jt, 4, identity)
+		},
+		"Debounce": func(jt *jet.Jet) *jet.Jet {
+			return jet.Debounce(jt, time.Millisecond)
+		},
+		"RateLimit": func(jt *jet.Jet) *jet.Jet {
+			return jet.RateLimit(jt, ratelimit.NewTokenBucket(time.Millisecond, 1), jet.Delay)
+		},
+	}
+
+	for name, operator := range operators {
+		operator := operator
+		t.Run(name+" source closed", func(t *testing.T) {
+			defer leakcheck.Check(t)()
+			source := jet.New()
+			out := operator(source)
+			sink := out.Sink()
+			for i := 0; i < 3; i++ {
+				source.Up(i)
+			}
+			source.Close()
+			for range sink {
+			}
+		})
+		t.Run(name+" output closed", func(t *testing.T) {
+			defer leakcheck.Check(t)()
+			source := jet.New()
+			out := operator(source)
+			out.Close()
+			<-source.Done()
+		})
+	}
+}
@@ -8,7 +8,14 @@
 
 package jet
 
-import . "github.com/d-exclaimation/gocurrent/types"
+import (
+	"context"
+	"github.com/d-exclaimation/gocurrent/ratelimit"
+	"github.com/d-exclaimation/gocurrent/streaming"
+	"github.com/d-exclaimation/gocurrent/streaming/channel"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"time"
+)
 
 // Map is an operator for mapping the inner streaming value of the Jet
 func Map(jt *Jet, mapper func(Any) Any) *Jet {
@@ -21,13 +28,13 @@ func Map(jt *Jet, mapper func(Any) Any) *Jet {
 	}()
 
 	// Iterate over the current jet and close once done
+	ch := jt.Sink()
 	go func() {
-		ch := jt.Sink()
 		for snapshot := range ch {
 			newJet.Up(mapper(snapshot))
 		}
 		_ = jt.Detach(ch)
-		newJet.Close()
+		newJet.closeWith(jt.Err())
 	}()
 
 	return newJet
@@ -44,15 +51,15 @@ func Filter(jt *Jet, predicate func(Any) bool) *Jet {
 	}()
 
 	// Iterate over the current jet and close once done
+	ch := jt.Sink()
 	go func() {
-		ch := jt.Sink()
 		for snapshot := range ch {
 			if predicate(snapshot) {
 				newJet.Up(snapshot)
 			}
 		}
 		_ = jt.Detach(ch)
-		newJet.Close()
+		newJet.closeWith(jt.Err())
 	}()
 
 	return newJet
@@ -69,8 +76,8 @@ func FilterMap(jt *Jet, predicateMap func(Any) (bool, Any)) *Jet {
 	}()
 
 	// Iterate over the current jet and close once done
+	ch := jt.Sink()
 	go func() {
-		ch := jt.Sink()
 		for snapshot := range ch {
 			ok, res := predicateMap(snapshot)
 			if ok {
@@ -78,7 +85,130 @@ func FilterMap(jt *Jet, predicateMap func(Any) (bool, Any)) *Jet {
 			}
 		}
 		_ = jt.Detach(ch)
-		newJet.Close()
+		newJet.closeWith(jt.Err())
+	}()
+
+	return newJet
+}
+
+// ParallelMap is an operator for mapping the inner streaming value of the Jet concurrently while preserving order
+func ParallelMap(jt *Jet, workers int, mapper func(Any) Any) *Jet {
+	return parallel(jt, workers, mapper, channel.ParallelMap)
+}
+
+// ParallelMapUnordered is an operator for mapping the inner streaming value of the Jet concurrently without preserving order
+func ParallelMapUnordered(jt *Jet, workers int, mapper func(Any) Any) *Jet {
+	return parallel(jt, workers, mapper, channel.ParallelMapUnordered)
+}
+
+// parallel runs a parallel channel operator over the Jet's sink and push the result to a new Jet
+func parallel(
+	jt *Jet, workers int, mapper func(Any) Any,
+	operator func(context.Context, streaming.Consumer, int, func(Any) Any) streaming.Consumer,
+) *Jet {
+	newJet := New()
+
+	// Wait for finish signal from the new Jet
+	go func() {
+		<-newJet.Done()
+		jt.Close()
+	}()
+
+	// Iterate over the current jet and close once done
+	ch := jt.Sink()
+	go func() {
+		for snapshot := range operator(context.Background(), ch, workers, mapper) {
+			newJet.Up(snapshot)
+		}
+		_ = jt.Detach(ch)
+		newJet.closeWith(jt.Err())
+	}()
+
+	return newJet
+}
+
+// Debounce is an operator for emitting a value only after no other value came within the duration on the Jet's Clock
+func Debounce(jt *Jet, d time.Duration) *Jet {
+	newJet := New(WithClock(jt.clock))
+
+	// Wait for finish signal from the new Jet
+	go func() {
+		<-newJet.Done()
+		jt.Close()
+	}()
+
+	// Iterate over the current jet, emit the pending value once quiet, and close once done
+	ch := jt.Sink()
+	go func() {
+		var (
+			pending Any
+			timer   <-chan time.Time
+		)
+		for {
+			select {
+			case snapshot, ok := <-ch:
+				if !ok {
+					if timer != nil {
+						newJet.Up(pending)
+					}
+					_ = jt.Detach(ch)
+					newJet.closeWith(jt.Err())
+					return
+				}
+				pending = snapshot
+				timer = jt.clock.After(d)
+			case <-timer:
+				newJet.Up(pending)
+				timer = nil
+			}
+		}
+	}()
+
+	return newJet
+}
+
+// RateMode is how RateLimit handles a value without a permit
+type RateMode int
+
+const (
+	// Delay holds each value until a permit is granted
+	Delay RateMode = iota
+
+	// Drop discards each value without an available permit, reported to the Observer
+	Drop
+)
+
+// RateLimit is an operator for limiting the rate of the inner streaming value of the Jet with the Limiter
+func RateLimit(jt *Jet, limiter *ratelimit.Limiter, mode RateMode) *Jet {
+	newJet := New(WithClock(jt.clock))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Wait for finish signal from the new Jet
+	go func() {
+		<-newJet.Done()
+		cancel()
+		jt.Close()
+	}()
+
+	// Iterate over the current jet, push each value once permitted, and close once done
+	ch := jt.Sink()
+	go func() {
+		for snapshot := range ch {
+			switch mode {
+			case Drop:
+				if !limiter.Allow() {
+					newJet.observer.Dropped(newJet.name, 1)
+					continue
+				}
+			default:
+				if _, err := limiter.Wait(ctx).Await(); err != nil {
+					continue
+				}
+			}
+			newJet.Up(snapshot)
+		}
+		_ = jt.Detach(ch)
+		newJet.closeWith(jt.Err())
 	}()
 
 	return newJet
@@ -8,7 +8,11 @@
 
 package jet
 
-import "github.com/d-exclaimation/gocurrent/types"
+import (
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/observe"
+	"github.com/d-exclaimation/gocurrent/types"
+)
 
 // Option is a interface pattern to be used for constructing Jet streams
 type Option interface {
@@ -55,3 +59,37 @@ func (d notBuffered) implement() {}
 func WithNoBuffer() Option {
 	return notBuffered{}
 }
+
+// clocked is an Option for Jet stream with a specified Clock
+type clocked struct {
+	clock.Clock
+}
+
+func (c clocked) implement() {}
+
+// WithClock is an Option to replace the Clock used for time-based operators instead of clock.Default
+func WithClock(c clock.Clock) Option {
+	return clocked{c}
+}
+
+// observed is an Option for Jet stream with a specified Observer
+type observed struct {
+	observe.Observer
+}
+
+func (o observed) implement() {}
+
+// WithObserver is an Option to replace the Observer notified for metrics instead of observe.Default
+func WithObserver(o observe.Observer) Option {
+	return observed{o}
+}
+
+// named is an Option for Jet stream with a name
+type named string
+
+func (n named) implement() {}
+
+// WithName is an Option to name the Jet stream for the Observer
+func WithName(name string) Option {
+	return named(name)
+}
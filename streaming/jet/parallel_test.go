@@ -0,0 +1,44 @@
+package jet_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+func TestParallelMapOrder(t *testing.T) {
+	// The first value waits until the rest of the batch is mapped, so it finishes last
+	var wg sync.WaitGroup
+	wg.Add(3)
+	source := jet.New()
+	out := jet.ParallelMap(source, 4, func(v Any) Any {
+		switch {
+		case v == 0:
+			wg.Wait()
+		case v.(int) < 4:
+			wg.Done()
+		}
+		return v.(int) * 10
+	})
+	sink := out.Sink()
+
+	go func() {
+		for i := 0; i < 20; i++ {
+			source.Up(i)
+		}
+		source.Close()
+	}()
+
+	i := 0
+	for res := range sink {
+		if res != i*10 {
+			t.Fatalf("expected %d at %d, got %v", i*10, i, res)
+		}
+		i++
+	}
+	if i != 20 {
+		t.Fatalf("expected 20 results, got %d", i)
+	}
+}
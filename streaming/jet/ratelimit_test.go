@@ -0,0 +1,86 @@
+package jet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+	"github.com/d-exclaimation/gocurrent/observe"
+	"github.com/d-exclaimation/gocurrent/ratelimit"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	. "github.com/d-exclaimation/gocurrent/types"
+)
+
+func TestRateLimitDelay(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	source := jet.New()
+	out := jet.RateLimit(source, ratelimit.NewTokenBucket(time.Second, 1), jet.Delay)
+
+	received := make(chan Any, 3)
+	go func(sink <-chan Any) {
+		for snapshot := range sink {
+			received <- snapshot
+		}
+		close(received)
+	}(out.Sink())
+	go func() {
+		for i := 0; i < 3; i++ {
+			source.Up(i)
+		}
+		source.Close()
+	}()
+
+	if res := <-received; res != 0 {
+		t.Fatalf("expected the first value immediately, got %v", res)
+	}
+	for i := 1; i < 3; i++ {
+		vc.BlockUntil(1)
+		select {
+		case res := <-received:
+			t.Fatalf("expected %d to wait for a permit, got %v", i, res)
+		default:
+		}
+		vc.Advance(time.Second)
+		if res := <-received; res != i {
+			t.Fatalf("expected %d after a second, got %v", i, res)
+		}
+	}
+	if _, ok := <-received; ok {
+		t.Fatal("expected the output to close with the source")
+	}
+}
+
+func TestRateLimitDrop(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	memory := observe.NewMemory()
+	defer observe.SetDefault(memory)()
+
+	source := jet.New()
+	out := jet.RateLimit(source, ratelimit.NewTokenBucket(time.Second, 2), jet.Drop)
+	sink := out.Sink()
+	go func() {
+		for i := 0; i < 5; i++ {
+			source.Up(i)
+		}
+		source.Close()
+	}()
+
+	var res []Any
+	for snapshot := range sink {
+		res = append(res, snapshot)
+	}
+	if len(res) != 2 || res[0] != 0 || res[1] != 1 {
+		t.Fatalf("expected the burst to pass and the rest dropped, got %v", res)
+	}
+
+	dropped := int64(0)
+	for _, stats := range memory.Snapshot().Jets {
+		dropped += stats.Dropped
+	}
+	if dropped != 3 {
+		t.Fatalf("expected 3 dropped values reported, got %d", dropped)
+	}
+}
@@ -0,0 +1,163 @@
+//
+//  collector.go
+//  pipe
+//
+//  Created by d-exclaimation on 11:15 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package pipe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/task"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"strings"
+)
+
+// Partitioned is the result of Partition with values that met the predicate and the rest
+type Partitioned[T any] struct {
+	// Matched are the values that met the predicate
+	Matched []T
+
+	// Rest are the values that did not
+	Rest []T
+}
+
+// Count counts all values of the Jet once it closes
+func Count(jt *jet.Jet) *task.Task[int] {
+	s := sink[Any](jt, "Count")
+	return task.Async[int](func() (int, error) {
+		count := 0
+		err := s.each(func(_ Any) bool {
+			count++
+			return true
+		})
+		return count, err
+	})
+}
+
+// First takes the first value of the Jet and detach immediately, or fail if the Jet closed without any value
+func First[T any](jt *jet.Jet) *task.Task[T] {
+	s := sink[T](jt, "First")
+	return task.Async[T](func() (T, error) {
+		var (
+			res   T
+			found = false
+		)
+		err := s.each(func(snapshot T) bool {
+			res = snapshot
+			found = true
+			return false
+		})
+		if err == nil && !found {
+			err = errors.New("pipe 'First': Jet closed without any value")
+		}
+		return res, err
+	})
+}
+
+// Min takes the smallest value of the Jet according to the comparator, or fail if the Jet closed without any value
+func Min[T any](jt *jet.Jet, less func(T, T) bool) *task.Task[T] {
+	return extreme(sink[T](jt, "Min"), less)
+}
+
+// Max takes the largest value of the Jet according to the comparator, or fail if the Jet closed without any value
+func Max[T any](jt *jet.Jet, less func(T, T) bool) *task.Task[T] {
+	return extreme(sink[T](jt, "Max"), func(a, b T) bool {
+		return less(b, a)
+	})
+}
+
+// extreme takes the value that comes before every other value according to the comparator
+func extreme[T any](s *stream[T], before func(T, T) bool) *task.Task[T] {
+	return task.Async[T](func() (T, error) {
+		var (
+			res   T
+			found = false
+		)
+		err := s.each(func(snapshot T) bool {
+			if !found || before(snapshot, res) {
+				res = snapshot
+				found = true
+			}
+			return true
+		})
+		if err == nil && !found {
+			err = fmt.Errorf("pipe '%s': Jet closed without any value", s.op)
+		}
+		return res, err
+	})
+}
+
+// Sum adds all numeric values of the Jet once it closes
+func Sum[T Number](jt *jet.Jet) *task.Task[T] {
+	s := sink[T](jt, "Sum")
+	return task.Async[T](func() (T, error) {
+		var res T
+		err := s.each(func(snapshot T) bool {
+			res += snapshot
+			return true
+		})
+		return res, err
+	})
+}
+
+// ToMap collects all values of the Jet into a map by the given key, where later values replace earlier ones
+func ToMap[T any, K comparable](jt *jet.Jet, keyFn func(T) K) *task.Task[map[K]T] {
+	s := sink[T](jt, "ToMap")
+	return task.Async[map[K]T](func() (map[K]T, error) {
+		res := make(map[K]T)
+		err := s.each(func(snapshot T) bool {
+			res[keyFn(snapshot)] = snapshot
+			return true
+		})
+		return res, err
+	})
+}
+
+// GroupBy collects all values of the Jet into groups by the given key, preserving order within each group
+func GroupBy[T any, K comparable](jt *jet.Jet, keyFn func(T) K) *task.Task[map[K][]T] {
+	s := sink[T](jt, "GroupBy")
+	return task.Async[map[K][]T](func() (map[K][]T, error) {
+		res := make(map[K][]T)
+		err := s.each(func(snapshot T) bool {
+			key := keyFn(snapshot)
+			res[key] = append(res[key], snapshot)
+			return true
+		})
+		return res, err
+	})
+}
+
+// Partition splits all values of the Jet into values that met the predicate and the rest
+func Partition[T any](jt *jet.Jet, predicate func(T) bool) *task.Task[Partitioned[T]] {
+	s := sink[T](jt, "Partition")
+	return task.Async[Partitioned[T]](func() (Partitioned[T], error) {
+		res := Partitioned[T]{}
+		err := s.each(func(snapshot T) bool {
+			if predicate(snapshot) {
+				res.Matched = append(res.Matched, snapshot)
+			} else {
+				res.Rest = append(res.Rest, snapshot)
+			}
+			return true
+		})
+		return res, err
+	})
+}
+
+// Join formats all values of the Jet and concatenate them with the separator
+func Join(jt *jet.Jet, sep string) *task.Task[string] {
+	s := sink[Any](jt, "Join")
+	return task.Async[string](func() (string, error) {
+		var parts []string
+		err := s.each(func(snapshot Any) bool {
+			parts = append(parts, fmt.Sprint(snapshot))
+			return true
+		})
+		return strings.Join(parts, sep), err
+	})
+}
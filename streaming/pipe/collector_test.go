@@ -0,0 +1,106 @@
+package pipe_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/streaming/pipe"
+)
+
+// push emits the values into the Jet then closes it
+func push(jt *jet.Jet, values ...interface{}) {
+	go func() {
+		for _, value := range values {
+			jt.Up(value)
+		}
+		jt.Close()
+	}()
+}
+
+func TestCollectors(t *testing.T) {
+	jt := jet.New()
+	count := pipe.Count(jt)
+	sum := pipe.Sum[int](jt)
+	smallest := pipe.Min[int](jt, func(a, b int) bool { return a < b })
+	largest := pipe.Max[int](jt, func(a, b int) bool { return a < b })
+	groups := pipe.GroupBy[int, bool](jt, func(v int) bool { return v%2 == 0 })
+	parts := pipe.Partition[int](jt, func(v int) bool { return v > 2 })
+	joined := pipe.Join(jt, ",")
+	stats := pipe.Stats[int](jt)
+	last := pipe.Last[int](jt)
+	push(jt, 3, 1, 4, 2, 5)
+
+	if res, err := count.Await(); res != 5 || err != nil {
+		t.Errorf("Count: expected 5, got %v %v", res, err)
+	}
+	if res, err := sum.Await(); res != 15 || err != nil {
+		t.Errorf("Sum: expected 15, got %v %v", res, err)
+	}
+	if res, err := smallest.Await(); res != 1 || err != nil {
+		t.Errorf("Min: expected 1, got %v %v", res, err)
+	}
+	if res, err := largest.Await(); res != 5 || err != nil {
+		t.Errorf("Max: expected 5, got %v %v", res, err)
+	}
+	if res, err := groups.Await(); !reflect.DeepEqual(res, map[bool][]int{true: {4, 2}, false: {3, 1, 5}}) || err != nil {
+		t.Errorf("GroupBy: expected evens and odds in order, got %v %v", res, err)
+	}
+	if res, err := parts.Await(); !reflect.DeepEqual(res, pipe.Partitioned[int]{Matched: []int{3, 4, 5}, Rest: []int{1, 2}}) || err != nil {
+		t.Errorf("Partition: expected [3 4 5] and [1 2], got %v %v", res, err)
+	}
+	if res, err := joined.Await(); res != "3,1,4,2,5" || err != nil {
+		t.Errorf("Join: expected 3,1,4,2,5, got %q %v", res, err)
+	}
+	if res, err := last.Await(); res.OrElse(0) != 5 || err != nil {
+		t.Errorf("Last: expected 5, got %v %v", res, err)
+	}
+	res, err := stats.Await()
+	if err != nil || res.Count != 5 || res.Mean != 3 || res.Min != 1 || res.Max != 5 || res.Percentile(50) != 3 {
+		t.Errorf("Stats: unexpected summary %+v %v", res, err)
+	}
+}
+
+func TestCollectorsEmpty(t *testing.T) {
+	jt := jet.New()
+	first := pipe.First[int](jt)
+	smallest := pipe.Min[int](jt, func(a, b int) bool { return a < b })
+	count := pipe.Count(jt)
+	last := pipe.Last[int](jt)
+	jt.Close()
+
+	if _, err := first.Await(); err == nil {
+		t.Error("First: expected an error from an empty Jet")
+	}
+	if _, err := smallest.Await(); err == nil {
+		t.Error("Min: expected an error from an empty Jet")
+	}
+	if res, err := count.Await(); res != 0 || err != nil {
+		t.Errorf("Count: expected 0, got %v %v", res, err)
+	}
+	if res, err := last.Await(); res.IsSome() || err != nil {
+		t.Errorf("Last: expected none, got %v %v", res, err)
+	}
+}
+
+func TestCollectorUnexpectedType(t *testing.T) {
+	jt := jet.New()
+	sum := pipe.Sum[int](jt)
+	count := pipe.Count(jt)
+	push(jt, 1, "two", 3)
+
+	if _, err := sum.Await(); err == nil {
+		t.Error("Sum: expected an error for a value of the wrong type")
+	}
+
+	// The failed collector detached, so the Jet keeps emitting to the rest
+	select {
+	case <-count.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Count: the Jet was blocked by the failed collector")
+	}
+	if res, _ := count.Await(); res != 3 {
+		t.Errorf("Count: expected 3, got %v", res)
+	}
+}
@@ -0,0 +1,88 @@
+//
+//  search.go
+//  pipe
+//
+//  Created by d-exclaimation on 12:21 AM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package pipe
+
+import (
+	"errors"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+// Find takes the first value of the Jet that met the predicate and detach immediately,
+// or fail if the Jet closed without any matching value
+func Find[T any](jt *jet.Jet, predicate func(T) bool) *task.Task[T] {
+	s := sink[T](jt, "Find")
+	return task.Async[T](func() (T, error) {
+		var (
+			res   T
+			found = false
+		)
+		err := s.each(func(snapshot T) bool {
+			if predicate(snapshot) {
+				res = snapshot
+				found = true
+			}
+			return !found
+		})
+		if err == nil && !found {
+			err = errors.New("pipe 'Find': Jet closed without any value that met the predicate")
+		}
+		return res, err
+	})
+}
+
+// AnyMatch resolves to true as soon as a value of the Jet met the predicate, or false once the Jet closes
+func AnyMatch[T any](jt *jet.Jet, predicate func(T) bool) *task.Task[bool] {
+	return match(sink[T](jt, "AnyMatch"), predicate, true)
+}
+
+// AllMatch resolves to false as soon as a value of the Jet did not meet the predicate, or true once the Jet closes
+func AllMatch[T any](jt *jet.Jet, predicate func(T) bool) *task.Task[bool] {
+	return match(sink[T](jt, "AllMatch"), func(snapshot T) bool {
+		return !predicate(snapshot)
+	}, false)
+}
+
+// NoneMatch resolves to false as soon as a value of the Jet met the predicate, or true once the Jet closes
+func NoneMatch[T any](jt *jet.Jet, predicate func(T) bool) *task.Task[bool] {
+	return match(sink[T](jt, "NoneMatch"), predicate, false)
+}
+
+// match short-circuits with the given answer on the first value that met the predicate, otherwise give the opposite
+func match[T any](s *stream[T], predicate func(T) bool, answer bool) *task.Task[bool] {
+	return task.Async[bool](func() (bool, error) {
+		found := false
+		err := s.each(func(snapshot T) bool {
+			found = predicate(snapshot)
+			return !found
+		})
+		if found {
+			return answer, err
+		}
+		return !answer, err
+	})
+}
+
+// TakeSeq collects the first n values of the Jet into a slice and detach immediately,
+// or fewer if the Jet closed earlier
+func TakeSeq[T any](jt *jet.Jet, n int) *task.Task[[]T] {
+	s := sink[T](jt, "TakeSeq")
+	return task.Async[[]T](func() ([]T, error) {
+		if n <= 0 {
+			s.detach()
+			return []T{}, nil
+		}
+		seq := make([]T, 0, n)
+		err := s.each(func(snapshot T) bool {
+			seq = append(seq, snapshot)
+			return len(seq) < n
+		})
+		return seq, err
+	})
+}
@@ -0,0 +1,92 @@
+package pipe_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/streaming/pipe"
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+// settles fails the test if the Task did not settle in time
+func settles[T any](t *testing.T, tk *task.Task[T]) (T, error) {
+	t.Helper()
+	select {
+	case <-tk.Done():
+	case <-time.After(time.Second):
+		t.Fatal("collector never settled")
+	}
+	return tk.Await()
+}
+
+func TestShortCircuitDetach(t *testing.T) {
+	jt := jet.New()
+	first := pipe.First[int](jt)
+	found := pipe.Find[int](jt, func(v int) bool { return v == 3 })
+	anyMatch := pipe.AnyMatch[int](jt, func(v int) bool { return v > 1 })
+	allMatch := pipe.AllMatch[int](jt, func(v int) bool { return v < 2 })
+	noneMatch := pipe.NoneMatch[int](jt, func(v int) bool { return v == 0 })
+	taken := pipe.TakeSeq[int](jt, 2)
+	count := pipe.Count(jt)
+
+	// Every short-circuiting collector settles before the Jet closes
+	for i := 0; i < 5; i++ {
+		jt.Up(i)
+	}
+
+	if res, err := settles(t, first); res != 0 || err != nil {
+		t.Errorf("First: expected 0, got %v %v", res, err)
+	}
+	if res, err := settles(t, found); res != 3 || err != nil {
+		t.Errorf("Find: expected 3, got %v %v", res, err)
+	}
+	if res, err := settles(t, anyMatch); !res || err != nil {
+		t.Errorf("AnyMatch: expected true, got %v %v", res, err)
+	}
+	if res, err := settles(t, allMatch); res || err != nil {
+		t.Errorf("AllMatch: expected false, got %v %v", res, err)
+	}
+	if res, err := settles(t, noneMatch); res || err != nil {
+		t.Errorf("NoneMatch: expected false, got %v %v", res, err)
+	}
+	if res, err := settles(t, taken); !reflect.DeepEqual(res, []int{0, 1}) || err != nil {
+		t.Errorf("TakeSeq: expected [0 1], got %v %v", res, err)
+	}
+
+	// The detached collectors never block the Jet for the remaining consumers
+	for i := 0; i < 100; i++ {
+		jt.Up(i)
+	}
+	jt.Close()
+	if res, err := settles(t, count); res != 105 || err != nil {
+		t.Errorf("Count: expected 105, got %v %v", res, err)
+	}
+}
+
+func TestShortCircuitClosed(t *testing.T) {
+	jt := jet.New()
+	found := pipe.Find[int](jt, func(v int) bool { return v > 10 })
+	anyMatch := pipe.AnyMatch[int](jt, func(v int) bool { return v > 10 })
+	allMatch := pipe.AllMatch[int](jt, func(v int) bool { return v < 10 })
+	taken := pipe.TakeSeq[int](jt, 5)
+	zero := pipe.TakeSeq[int](jt, 0)
+	push(jt, 1, 2)
+
+	if _, err := settles(t, found); err == nil {
+		t.Error("Find: expected an error without any match")
+	}
+	if res, err := settles(t, anyMatch); res || err != nil {
+		t.Errorf("AnyMatch: expected false, got %v %v", res, err)
+	}
+	if res, err := settles(t, allMatch); !res || err != nil {
+		t.Errorf("AllMatch: expected true, got %v %v", res, err)
+	}
+	if res, err := settles(t, taken); !reflect.DeepEqual(res, []int{1, 2}) || err != nil {
+		t.Errorf("TakeSeq: expected the fewer values, got %v %v", res, err)
+	}
+	if res, err := settles(t, zero); len(res) != 0 || err != nil {
+		t.Errorf("TakeSeq: expected no values, got %v %v", res, err)
+	}
+}
@@ -9,44 +9,54 @@
 package pipe
 
 import (
+	"github.com/d-exclaimation/gocurrent/option"
 	"github.com/d-exclaimation/gocurrent/streaming/jet"
 	"github.com/d-exclaimation/gocurrent/task"
-	. "github.com/d-exclaimation/gocurrent/types"
 )
 
-func Seq(jt *jet.Jet) *task.Task[Any] {
-	ch := jt.Sink()
-	return task.Async[Any](func() (Any, error) {
-		var seq []Any
-		for snapshot := range ch {
+// Seq collects all values of the Jet into a slice once it closes
+func Seq[T any](jt *jet.Jet) *task.Task[[]T] {
+	s := sink[T](jt, "Seq")
+	return task.Async[[]T](func() ([]T, error) {
+		var seq []T
+		err := s.each(func(snapshot T) bool {
 			seq = append(seq, snapshot)
-		}
-		return seq, nil
+			return true
+		})
+		return seq, err
 	})
 }
 
-func Last(jt *jet.Jet) *task.Task[Any] {
-	ch := jt.Sink()
-	return task.Async[Any](func() (Any, error) {
-		var res Any
-		for snapshot := range ch {
-			res = snapshot
-		}
-		return res, nil
+// Last takes the last value of the Jet once it closes, none if the Jet closed without any value
+func Last[T any](jt *jet.Jet) *task.Task[option.Option[T]] {
+	s := sink[T](jt, "Last")
+	return task.Async[option.Option[T]](func() (option.Option[T], error) {
+		res := option.None[T]()
+		err := s.each(func(snapshot T) bool {
+			res = option.Some(snapshot)
+			return true
+		})
+		return res, err
 	})
 }
 
-func Reduce(jt *jet.Jet, reducer func(Any, Any) Any) *task.Task[Any] {
-	ch := jt.Sink()
-	return task.Async[Any](func() (Any, error) {
-		var res Any = nil
-		for snapshot := range ch {
-			if res == nil {
+// Reduce combines all values of the Jet using the reducer, starting with the first value
+func Reduce[T any](jt *jet.Jet, reducer func(T, T) T) *task.Task[T] {
+	s := sink[T](jt, "Reduce")
+	return task.Async[T](func() (T, error) {
+		var (
+			res     T
+			started = false
+		)
+		err := s.each(func(snapshot T) bool {
+			if !started {
 				res = snapshot
+				started = true
 			} else {
 				res = reducer(res, snapshot)
 			}
-		}
-		return res, nil
+			return true
+		})
+		return res, err
 	})
 }
@@ -0,0 +1,81 @@
+//
+//  stats.go
+//  pipe
+//
+//  Created by d-exclaimation on 11:40 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package pipe
+
+import (
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/task"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"math"
+	"sort"
+)
+
+// Statistics is a summary of numeric values from a Jet
+type Statistics struct {
+	// Count is the number of values
+	Count int
+
+	// Sum is the total of all values
+	Sum float64
+
+	// Mean is the average of all values
+	Mean float64
+
+	// Min is the smallest value
+	Min float64
+
+	// Max is the largest value
+	Max float64
+
+	// sorted are all values in ascending order for percentiles
+	sorted []float64
+}
+
+// Percentile return the p-th percentile (0 to 100) using linear interpolation, or NaN if there are no values
+func (s Statistics) Percentile(p float64) float64 {
+	if len(s.sorted) == 0 {
+		return math.NaN()
+	}
+	if p <= 0 {
+		return s.sorted[0]
+	}
+	if p >= 100 {
+		return s.sorted[len(s.sorted)-1]
+	}
+	rank := p / 100 * float64(len(s.sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	weight := rank - float64(lower)
+	return s.sorted[lower]*(1-weight) + s.sorted[upper]*weight
+}
+
+// Stats summarizes all numeric values of the Jet once it closes
+func Stats[T Number](jt *jet.Jet) *task.Task[Statistics] {
+	s := sink[T](jt, "Stats")
+	return task.Async[Statistics](func() (Statistics, error) {
+		res := Statistics{}
+		err := s.each(func(snapshot T) bool {
+			res.sorted = append(res.sorted, float64(snapshot))
+			return true
+		})
+		if err != nil || len(res.sorted) == 0 {
+			return res, err
+		}
+
+		sort.Float64s(res.sorted)
+		res.Count = len(res.sorted)
+		res.Min = res.sorted[0]
+		res.Max = res.sorted[res.Count-1]
+		for _, value := range res.sorted {
+			res.Sum += value
+		}
+		res.Mean = res.Sum / float64(res.Count)
+		return res, nil
+	})
+}
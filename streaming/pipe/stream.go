@@ -0,0 +1,71 @@
+//
+//  stream.go
+//  pipe
+//
+//  Created by d-exclaimation on 11:02 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package pipe
+
+import (
+	"fmt"
+	"github.com/d-exclaimation/gocurrent/streaming"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"reflect"
+)
+
+// stream is a typed consumer of a Jet's sink
+type stream[T any] struct {
+	// jt is the Jet source
+	jt *jet.Jet
+
+	// ch is the registered consumer channel
+	ch streaming.Consumer
+
+	// op is the name of the collector for error reporting
+	op string
+}
+
+// sink registers a consumer channel immediately, so no value pushed after the call is missed
+func sink[T any](jt *jet.Jet, op string) *stream[T] {
+	return &stream[T]{
+		jt: jt,
+		ch: jt.Sink(),
+		op: op,
+	}
+}
+
+// each iterates over the values until the callback returns false or the Jet closes, and detach the sink afterwards
+func (s *stream[T]) each(callback func(T) bool) error {
+	defer s.detach()
+	for snapshot := range s.ch {
+		value, ok := snapshot.(T)
+		if !ok && !(snapshot == nil && nillable[T]()) {
+			return fmt.Errorf("pipe '%s': Unexpected value of type %T", s.op, snapshot)
+		}
+		if !callback(value) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// nillable indicates whether nil is a valid value of the type
+func nillable[T any]() bool {
+	switch reflect.TypeOf((*T)(nil)).Elem().Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return true
+	default:
+		return false
+	}
+}
+
+// detach unregisters the consumer channel while draining it, so the Jet is never blocked on an abandoned channel
+func (s *stream[T]) detach() {
+	go func() {
+		for range s.ch {
+		}
+	}()
+	_ = s.jt.Detach(s.ch)
+}
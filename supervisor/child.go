@@ -0,0 +1,158 @@
+//
+//  child.go
+//  supervisor
+//
+//  Created by d-exclaimation on 3:41 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/d-exclaimation/gocurrent/actor"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+// Restart is the policy on when a child should be restarted
+type Restart int
+
+const (
+	// Permanent children are always restarted
+	Permanent Restart = iota
+
+	// Transient children are restarted only when they failed
+	Transient
+
+	// Temporary children are never restarted
+	Temporary
+)
+
+// Child is the specification of a supervised unit of work
+type Child struct {
+	// Name is the identifier used in events
+	Name string
+
+	// Run is the blocking work, which should return once the context is cancelled
+	Run func(ctx context.Context) error
+
+	// Restart is the policy on when the child should be restarted
+	Restart Restart
+}
+
+// shouldRestart decides whether the child should be restarted after exiting with the error
+func (c Child) shouldRestart(err error) bool {
+	switch c.Restart {
+	case Permanent:
+		return true
+	case Transient:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// protect runs the child and convert any panic into an error
+func (c Child) protect(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("supervisor: Child '%s' panicked with %v", c.Name, r)
+		}
+	}()
+	return c.Run(ctx)
+}
+
+// Jet is a permanent child that runs a Jet and restarts it whenever it closes.
+//
+// Note: The RunnableJet must create a new Jet on each call, jet.Lazy can only be run once.
+func Jet(name string, run jet.RunnableJet) Child {
+	return Child{
+		Name:    name,
+		Restart: Permanent,
+		Run: func(ctx context.Context) error {
+			jt := run()
+			ch := jt.Sink()
+			go func() {
+				<-ctx.Done()
+				jt.Close()
+			}()
+			for range ch {
+			}
+			if err := jt.Err(); err != nil {
+				return err
+			}
+			if ctx.Err() == nil {
+				return errors.New("supervisor: Jet closed unexpectedly")
+			}
+			return nil
+		},
+	}
+}
+
+// Task is a transient child that runs a Task from the factory and restarts it whenever it failed
+//
+// Note: A Task cannot be cancelled, cancellation only stops waiting for it.
+func Task[T any](name string, factory func() *task.Task[T]) Child {
+	return Child{
+		Name:    name,
+		Restart: Transient,
+		Run: func(ctx context.Context) error {
+			select {
+			case res := <-factory().AwaitChannel():
+				_, err := res.ToOption()
+				return err
+			case <-ctx.Done():
+				return nil
+			}
+		},
+	}
+}
+
+// Actor is a permanent child that spawns an actor and restarts it whenever it stopped
+func Actor[M any](name string, spawn func() *actor.Actor[M]) Child {
+	return Child{
+		Name:    name,
+		Restart: Permanent,
+		Run: func(ctx context.Context) error {
+			ac := spawn()
+			select {
+			case <-ac.Done():
+				if err := ac.Err(); err != nil {
+					return err
+				}
+				return errors.New("supervisor: Actor stopped unexpectedly")
+			case <-ctx.Done():
+				ac.Stop()
+				<-ac.Done()
+				return nil
+			}
+		},
+	}
+}
+
+// Nested is a permanent child that runs a Supervisor from the factory as a subtree, which fails once it escalated,
+// so the parent's strategy applies
+func Nested(name string, spawn func() *Supervisor) Child {
+	return Child{
+		Name:    name,
+		Restart: Permanent,
+		Run: func(ctx context.Context) error {
+			sup := spawn()
+			sup.Run()
+			select {
+			case <-sup.Done():
+				if err := sup.Err(); err != nil {
+					return err
+				}
+				return errors.New("supervisor: Nested supervisor stopped unexpectedly")
+			case <-ctx.Done():
+				sup.Stop()
+				<-sup.Done()
+				return nil
+			}
+		},
+	}
+}
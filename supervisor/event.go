@@ -0,0 +1,69 @@
+//
+//  event.go
+//  supervisor
+//
+//  Created by d-exclaimation on 3:52 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package supervisor
+
+import "time"
+
+// EventKind is the kind of lifecycle event
+type EventKind int
+
+const (
+	// Started is when a child started
+	Started EventKind = iota
+
+	// Exited is when a child returned without error
+	Exited
+
+	// Failed is when a child returned an error or panicked
+	Failed
+
+	// Restarting is when a child is about to be restarted
+	Restarting
+
+	// Stopped is when a child is stopped by the supervisor
+	Stopped
+
+	// Escalated is when the supervisor gave up after exceeding the restart intensity
+	Escalated
+)
+
+// String return the name of the event kind
+func (k EventKind) String() string {
+	switch k {
+	case Started:
+		return "started"
+	case Exited:
+		return "exited"
+	case Failed:
+		return "failed"
+	case Restarting:
+		return "restarting"
+	case Stopped:
+		return "stopped"
+	case Escalated:
+		return "escalated"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a lifecycle event of a supervised child
+type Event struct {
+	// Child is the name of the child, empty for supervisor-wide events
+	Child string
+
+	// Kind is the kind of event
+	Kind EventKind
+
+	// Err is the failure related to the event if any
+	Err error
+
+	// Time is when the event happened
+	Time time.Time
+}
@@ -0,0 +1,43 @@
+//
+//  option.go
+//  supervisor
+//
+//  Created by d-exclaimation on 4:05 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package supervisor
+
+import "time"
+
+// Option is a interface pattern to be used for constructing supervisors
+type Option interface {
+	// implement is a required method for allowing any settings to follow Option
+	implement()
+}
+
+// intensity is an Option for supervisor with a restart limit within a period
+type intensity struct {
+	restarts int
+	period   time.Duration
+}
+
+func (i intensity) implement() {}
+
+// WithIntensity is an Option to limit the number of restarts within a period before the supervisor gives up
+func WithIntensity(restarts int, period time.Duration) Option {
+	return intensity{restarts: restarts, period: period}
+}
+
+// backoff is an Option for supervisor with exponential delay between restarts
+type backoff struct {
+	min time.Duration
+	max time.Duration
+}
+
+func (b backoff) implement() {}
+
+// WithBackoff is an Option to delay restarts exponentially, starting from min and capped at max
+func WithBackoff(min, max time.Duration) Option {
+	return backoff{min: min, max: max}
+}
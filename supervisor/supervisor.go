@@ -0,0 +1,391 @@
+//
+//  supervisor.go
+//  supervisor
+//
+//  Created by d-exclaimation on 3:20 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"github.com/d-exclaimation/gocurrent/streaming/jet"
+	. "github.com/d-exclaimation/gocurrent/types"
+	"sync"
+	"time"
+)
+
+// ErrIntensity is the error when the supervisor gave up after too many restarts
+var ErrIntensity = errors.New("supervisor: Restart intensity exceeded")
+
+// Strategy is how the supervisor restarts its children when one of them exits
+type Strategy int
+
+const (
+	// OneForOne restarts only the child that exited
+	OneForOne Strategy = iota
+
+	// OneForAll restarts all children when one of them exits
+	OneForAll
+
+	// RestForOne restarts the child that exited and every child started after it
+	RestForOne
+)
+
+// running is the state of a started child
+type running struct {
+	// cancel stops the child's context
+	cancel context.CancelFunc
+
+	// done is closed once the child returned
+	done chan Signal
+
+	// generation is the identifier of this run
+	generation int
+}
+
+// exit is the notification of a child returning
+type exit struct {
+	index      int
+	generation int
+	err        error
+}
+
+// Supervisor is a goroutine that starts children in order and restarts them according to a Strategy.
+//
+//  sup := supervisor.New(supervisor.OneForOne, []supervisor.Child{
+//      supervisor.Jet("ticker", newTicker),
+//      supervisor.Task("warmup", warmCache),
+//  })
+//  sup.Events().On(func(ev Any) {
+//      log.Println(ev)
+//  })
+//  sup.Run()
+//
+// Restarts are limited by the intensity, after which the supervisor stops all children and escalates.
+type Supervisor struct {
+	// strategy is the restart strategy
+	strategy Strategy
+
+	// children are the specifications in starting order
+	children []Child
+
+	// running are the states of the started children, nil if not running
+	running []*running
+
+	// generation is the counter for identifying each run
+	generation int
+
+	// restarts are the times of the recent restarts within the intensity period
+	restarts []time.Time
+
+	// intensity is the maximum restarts within the period
+	intensity int
+
+	// period is the sliding window for the intensity
+	period time.Duration
+
+	// minBackoff is the delay of the first restart
+	minBackoff time.Duration
+
+	// maxBackoff is the maximum delay between restarts
+	maxBackoff time.Duration
+
+	// exits is the channel for children exit notification
+	exits chan exit
+
+	// acid is the shutdown channel
+	acid chan Signal
+
+	// done is closed once the supervisor stopped
+	done chan Signal
+
+	// starting guards running the supervisor once
+	starting sync.Once
+
+	// stopping guards closing the acid channel
+	stopping sync.Once
+
+	// events is the Jet for lifecycle events
+	events *jet.Jet
+
+	// pending are the events not yet published, in order
+	pending []Event
+
+	// pendingMutex guards the pending events
+	pendingMutex sync.Mutex
+
+	// wake signals the publisher that there are pending events
+	wake chan Signal
+
+	// failure is the reason the supervisor stopped if it gave up
+	failure error
+}
+
+// New instantiate a new Supervisor without starting it, so every event is observed if Events is subscribed to before Run
+func New(strategy Strategy, children []Child, opts ...Option) *Supervisor {
+	sup := &Supervisor{
+		strategy:  strategy,
+		children:  children,
+		running:   make([]*running, len(children)),
+		intensity: 3,
+		period:    5 * time.Second,
+		exits:     make(chan exit),
+		acid:      make(chan Signal),
+		done:      make(chan Signal),
+		events:    jet.New(),
+		wake:      make(chan Signal, 1),
+	}
+
+	// Setup for optional fields and configuration
+	for _, opt := range opts {
+		switch opt.(type) {
+		case intensity:
+			limit := opt.(intensity)
+			sup.intensity = limit.restarts
+			sup.period = limit.period
+		case backoff:
+			delay := opt.(backoff)
+			sup.minBackoff = delay.min
+			sup.maxBackoff = delay.max
+		}
+	}
+
+	return sup
+}
+
+// Run starts all the children and the events publisher in separate goroutines, ignored if already started or stopped
+func (s *Supervisor) Run() {
+	s.starting.Do(func() {
+		go s.publish()
+		go s.receive()
+	})
+}
+
+// receive is method for actor-like behavior for handling children exits
+func (s *Supervisor) receive() {
+	defer close(s.done)
+
+	for i := range s.children {
+		s.start(i)
+	}
+
+	for {
+		select {
+		case ex := <-s.exits:
+			curr := s.running[ex.index]
+			if curr == nil || curr.generation != ex.generation {
+				continue
+			}
+			s.running[ex.index] = nil
+
+			child := s.children[ex.index]
+			if ex.err != nil {
+				s.emit(child.Name, Failed, ex.err)
+			} else {
+				s.emit(child.Name, Exited, nil)
+			}
+
+			if !child.shouldRestart(ex.err) {
+				continue
+			}
+
+			if !s.allow(time.Now()) {
+				s.stopAll()
+				s.failure = ErrIntensity
+				s.emit("", Escalated, ErrIntensity)
+				return
+			}
+
+			affected := s.affected(ex.index)
+			for i := len(affected) - 1; i >= 0; i-- {
+				s.stop(affected[i])
+			}
+			for _, i := range affected {
+				s.emit(s.children[i].Name, Restarting, nil)
+			}
+
+			select {
+			case <-time.After(s.delay()):
+			case <-s.acid:
+				s.stopAll()
+				return
+			}
+
+			for _, i := range affected {
+				s.start(i)
+			}
+
+		case <-s.acid:
+			s.stopAll()
+			return
+		}
+	}
+}
+
+// start runs the child at the index in a new goroutine
+func (s *Supervisor) start(index int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.generation++
+	curr := &running{
+		cancel:     cancel,
+		done:       make(chan Signal),
+		generation: s.generation,
+	}
+	s.running[index] = curr
+	child := s.children[index]
+
+	go func() {
+		err := child.protect(ctx)
+		cancel()
+		close(curr.done)
+		select {
+		case s.exits <- exit{index: index, generation: curr.generation, err: err}:
+		case <-s.done:
+		}
+	}()
+
+	s.emit(child.Name, Started, nil)
+}
+
+// stop cancels the child at the index and waits for it to return
+func (s *Supervisor) stop(index int) {
+	curr := s.running[index]
+	if curr == nil {
+		return
+	}
+	s.running[index] = nil
+	curr.cancel()
+	<-curr.done
+	s.emit(s.children[index].Name, Stopped, nil)
+}
+
+// stopAll stops all children in reverse starting order
+func (s *Supervisor) stopAll() {
+	for i := len(s.children) - 1; i >= 0; i-- {
+		s.stop(i)
+	}
+}
+
+// affected return the indexes of children to be restarted according to the strategy
+func (s *Supervisor) affected(index int) []int {
+	var res []int
+	switch s.strategy {
+	case OneForAll:
+		for i := range s.children {
+			res = append(res, i)
+		}
+	case RestForOne:
+		for i := index; i < len(s.children); i++ {
+			res = append(res, i)
+		}
+	default:
+		res = append(res, index)
+	}
+	return res
+}
+
+// allow records a restart and return false if it exceeded the intensity
+func (s *Supervisor) allow(now time.Time) bool {
+	recent := s.restarts[:0]
+	for _, at := range s.restarts {
+		if now.Sub(at) < s.period {
+			recent = append(recent, at)
+		}
+	}
+	s.restarts = append(recent, now)
+	return len(s.restarts) <= s.intensity
+}
+
+// delay return the exponential backoff for the current number of recent restarts
+func (s *Supervisor) delay() time.Duration {
+	if s.minBackoff <= 0 {
+		return 0
+	}
+	delay := s.minBackoff
+	for i := 1; i < len(s.restarts); i++ {
+		delay *= 2
+		if s.maxBackoff > 0 && delay >= s.maxBackoff {
+			return s.maxBackoff
+		}
+	}
+	return delay
+}
+
+// emit queues a lifecycle event to be published
+func (s *Supervisor) emit(name string, kind EventKind, err error) {
+	s.pendingMutex.Lock()
+	s.pending = append(s.pending, Event{
+		Child: name,
+		Kind:  kind,
+		Err:   err,
+		Time:  time.Now(),
+	})
+	s.pendingMutex.Unlock()
+
+	select {
+	case s.wake <- Signal{}:
+	default:
+	}
+}
+
+// publish pushes pending events into the Jet in order, and closes it with the rest once the supervisor stopped
+func (s *Supervisor) publish() {
+	for {
+		select {
+		case <-s.wake:
+			s.flush()
+		case <-s.done:
+			s.flush()
+			s.events.Close()
+			return
+		}
+	}
+}
+
+// flush pushes the events pending so far into the Jet
+func (s *Supervisor) flush() {
+	s.pendingMutex.Lock()
+	batch := s.pending
+	s.pending = nil
+	s.pendingMutex.Unlock()
+
+	for _, ev := range batch {
+		s.events.Up(ev)
+	}
+}
+
+// Events return the Jet of lifecycle events, which closes once the supervisor stopped
+//
+// Events are published in order from a separate goroutine, so a slow consumer never delays any restart or Stop.
+func (s *Supervisor) Events() *jet.Jet {
+	return s.events
+}
+
+// Stop shutdown the supervisor and all children in reverse starting order, or finish it immediately if never started
+func (s *Supervisor) Stop() {
+	s.stopping.Do(func() {
+		close(s.acid)
+	})
+	s.starting.Do(func() {
+		s.events.Close()
+		close(s.done)
+	})
+}
+
+// Done returns a channel that is closed once the supervisor stopped
+func (s *Supervisor) Done() <-chan Signal {
+	return s.done
+}
+
+// Err return the reason the supervisor stopped, nil if stopped normally or still running
+func (s *Supervisor) Err() error {
+	select {
+	case <-s.done:
+		return s.failure
+	default:
+		return nil
+	}
+}
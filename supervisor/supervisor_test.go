@@ -0,0 +1,199 @@
+package supervisor_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/supervisor"
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+var errCrash = errors.New("crash")
+
+// idle is a Task child that runs until it is stopped
+func idle(name string) supervisor.Child {
+	return supervisor.Task(name, func() *task.Task[int] {
+		return task.Maybe[int]().Task()
+	})
+}
+
+// crashing is a Task child that always fails
+func crashing(name string) supervisor.Child {
+	return supervisor.Task(name, func() *task.Task[int] {
+		return task.Async(func() (int, error) {
+			return 0, errCrash
+		})
+	})
+}
+
+// record collects every event of the supervisor until it stopped
+func record(sup *supervisor.Supervisor) <-chan []supervisor.Event {
+	res := make(chan []supervisor.Event, 1)
+	sink := sup.Events().Sink()
+	go func() {
+		var events []supervisor.Event
+		for ev := range sink {
+			events = append(events, ev.(supervisor.Event))
+		}
+		res <- events
+	}()
+	return res
+}
+
+// stopped waits for the supervisor to stop
+func stopped(t *testing.T, sup *supervisor.Supervisor) {
+	t.Helper()
+	select {
+	case <-sup.Done():
+	case <-time.After(time.Second):
+		t.Fatal("supervisor never stopped")
+	}
+}
+
+func TestInitialEvents(t *testing.T) {
+	sup := supervisor.New(supervisor.OneForOne, []supervisor.Child{idle("a"), idle("b")})
+	events := record(sup)
+	sup.Run()
+	sup.Stop()
+	stopped(t, sup)
+
+	expected := []struct {
+		child string
+		kind  supervisor.EventKind
+	}{
+		{"a", supervisor.Started},
+		{"b", supervisor.Started},
+		{"b", supervisor.Stopped},
+		{"a", supervisor.Stopped},
+	}
+	res := <-events
+	if len(res) != len(expected) {
+		t.Fatalf("expected %d events, got %v", len(expected), res)
+	}
+	for i, ev := range res {
+		if ev.Child != expected[i].child || ev.Kind != expected[i].kind {
+			t.Fatalf("expected %s %v at %d, got %s %v", expected[i].child, expected[i].kind, i, ev.Child, ev.Kind)
+		}
+	}
+	if sup.Err() != nil {
+		t.Fatalf("expected a normal stop, got %v", sup.Err())
+	}
+}
+
+func TestStopBeforeRun(t *testing.T) {
+	sup := supervisor.New(supervisor.OneForOne, []supervisor.Child{idle("a")})
+	events := record(sup)
+	sup.Stop()
+	sup.Run()
+	stopped(t, sup)
+	if res := <-events; len(res) != 0 {
+		t.Fatalf("expected no child to start, got %v", res)
+	}
+}
+
+func TestEscalate(t *testing.T) {
+	sup := supervisor.New(supervisor.OneForOne, []supervisor.Child{crashing("a")},
+		supervisor.WithIntensity(2, time.Minute),
+	)
+	events := record(sup)
+	sup.Run()
+	stopped(t, sup)
+
+	if !errors.Is(sup.Err(), supervisor.ErrIntensity) {
+		t.Fatalf("expected ErrIntensity, got %v", sup.Err())
+	}
+	restarts := 0
+	res := <-events
+	for _, ev := range res {
+		if ev.Kind == supervisor.Restarting {
+			restarts++
+		}
+	}
+	if restarts != 2 || res[len(res)-1].Kind != supervisor.Escalated {
+		t.Fatalf("expected 2 restarts then escalation, got %v", res)
+	}
+}
+
+func TestNestedEscalation(t *testing.T) {
+	spawned := 0
+	inner := func() *supervisor.Supervisor {
+		spawned++
+		return supervisor.New(supervisor.OneForOne, []supervisor.Child{crashing("leaf")},
+			supervisor.WithIntensity(0, time.Minute),
+		)
+	}
+	sup := supervisor.New(supervisor.OneForAll, []supervisor.Child{supervisor.Nested("inner", inner), idle("sibling")},
+		supervisor.WithIntensity(1, time.Minute),
+	)
+	events := record(sup)
+	sup.Run()
+	stopped(t, sup)
+
+	if !errors.Is(sup.Err(), supervisor.ErrIntensity) {
+		t.Fatalf("expected the parent to escalate, got %v", sup.Err())
+	}
+	if spawned != 2 {
+		t.Fatalf("expected the subtree to be restarted once, got %d runs", spawned)
+	}
+
+	failed, siblingRestarts := 0, 0
+	for _, ev := range <-events {
+		switch {
+		case ev.Child == "inner" && ev.Kind == supervisor.Failed:
+			if !errors.Is(ev.Err, supervisor.ErrIntensity) {
+				t.Fatalf("expected the subtree to fail with its escalation, got %v", ev.Err)
+			}
+			failed++
+		case ev.Child == "sibling" && ev.Kind == supervisor.Restarting:
+			siblingRestarts++
+		}
+	}
+	if failed != 2 || siblingRestarts != 1 {
+		t.Fatalf("expected the parent's strategy to restart the sibling, got %d failures and %d restarts", failed, siblingRestarts)
+	}
+}
+
+func TestNestedStop(t *testing.T) {
+	var nested *supervisor.Supervisor
+	ready := make(chan struct{})
+	sup := supervisor.New(supervisor.OneForOne, []supervisor.Child{
+		supervisor.Nested("inner", func() *supervisor.Supervisor {
+			nested = supervisor.New(supervisor.OneForOne, []supervisor.Child{idle("leaf")})
+			close(ready)
+			return nested
+		}),
+	})
+	sup.Run()
+	<-ready
+	sup.Stop()
+	stopped(t, sup)
+	stopped(t, nested)
+	if nested.Err() != nil {
+		t.Fatalf("expected the subtree to stop normally, got %v", nested.Err())
+	}
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	sup := supervisor.New(supervisor.OneForOne, []supervisor.Child{crashing("a")},
+		supervisor.WithIntensity(50, time.Minute),
+	)
+
+	// A subscriber that never reads does not hold back restarts
+	sink := sup.Events().Sink()
+	sup.Run()
+	stopped(t, sup)
+	if !errors.Is(sup.Err(), supervisor.ErrIntensity) {
+		t.Fatalf("expected every restart to run, got %v", sup.Err())
+	}
+
+	restarts := 0
+	for ev := range sink {
+		if ev.(supervisor.Event).Kind == supervisor.Restarting {
+			restarts++
+		}
+	}
+	if restarts != 50 {
+		t.Fatalf("expected every event to be published, got %d restarts", restarts)
+	}
+}
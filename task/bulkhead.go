@@ -0,0 +1,35 @@
+//
+//  bulkhead.go
+//  task
+//
+//  Created by d-exclaimation on 6:40 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package task
+
+import "github.com/d-exclaimation/gocurrent/try"
+
+// Bulkhead limits how many Tasks run at once for a dependency
+type Bulkhead interface {
+	// Enter calls the function with the function to leave once admitted, or with the error if rejected
+	Enter(admitted func(leave func(), err error))
+}
+
+// WithBulkhead runs the function in a new Task once the Bulkhead admits it, failing with its error if rejected.
+//
+// No goroutine is started until the function is admitted, so a rejected or waiting call costs none.
+func WithBulkhead[T any](b Bulkhead, op func() (T, error)) *Task[T] {
+	p := Maybe[T]()
+	b.Enter(func(leave func(), err error) {
+		if err != nil {
+			_ = p.Failure(err)
+			return
+		}
+		go func() {
+			defer leave()
+			p.TryComplete(try.Catch[T](op))
+		}()
+	})
+	return p.Task()
+}
@@ -0,0 +1,308 @@
+//
+//  cache.go
+//  task
+//
+//  Created by d-exclaimation on 9:31 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package task
+
+import (
+	"container/list"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/try"
+	"sync"
+	"time"
+)
+
+// Cache is a loading cache of Tasks by key, where concurrent lookups of a missing key share one in-flight load.
+//
+//  users := task.NewCache[string, User](fetchUser,
+//      task.WithTTL(time.Minute),
+//      task.WithStaleWhileRevalidate(10*time.Second),
+//      task.WithMaxSize(1000, task.LRU),
+//  )
+//  user, err := users.Get("user:1").Await()
+//
+// Use NewTimedCache for a loader that decides the TTL of each entry.
+type Cache[K comparable, T any] struct {
+	// loader is the function to load a value for a key with its own TTL
+	loader func(K) (T, time.Duration, error)
+
+	// entries are the cached entries by key
+	entries map[K]*entry[K, T]
+
+	// recency is the entries ordered from the most recently used
+	recency *list.List
+
+	// mutex guards the entries and recency
+	mutex sync.Mutex
+
+	// clock is the source of time captured from clock.Default
+	clock clock.Clock
+
+	// ttl is how long a value is fresh, never expired if zero
+	ttl time.Duration
+
+	// negativeTTL is how long an error is cached, not cached if zero
+	negativeTTL time.Duration
+
+	// stale is how long an expired value is still served while reloading
+	stale time.Duration
+
+	// ahead is how long before expiry a value is reloaded on access
+	ahead time.Duration
+
+	// size is the maximum number of entries, unbounded if zero
+	size int
+
+	// eviction is the policy for choosing which entry to remove once full
+	eviction Eviction
+}
+
+// entry is a cached Task with its freshness and usage
+type entry[K comparable, T any] struct {
+	key  K
+	task *Task[T]
+
+	// settled indicates whether the Task has settled and expiresAt is set
+	settled bool
+
+	// expiresAt is the time the value is no longer fresh, zero if never
+	expiresAt time.Time
+
+	// ttl is the entry's own TTL kept across refreshes, the Cache's if zero and never expired if negative
+	ttl time.Duration
+
+	// refreshing indicates whether a reload is in flight
+	refreshing bool
+
+	// hits is the number of lookups
+	hits int
+
+	// element is the position in the recency list
+	element *list.Element
+}
+
+// NewCache creates a new Cache that loads missing values with the loader
+func NewCache[K comparable, T any](loader func(K) (T, error), opts ...CacheOption) *Cache[K, T] {
+	return NewTimedCache[K, T](func(key K) (T, time.Duration, error) {
+		res, err := loader(key)
+		return res, 0, err
+	}, opts...)
+}
+
+// NewTimedCache creates a new Cache that loads missing values with the loader, which also return the TTL of the
+// entry where zero uses the Cache's TTL and negative never expires
+func NewTimedCache[K comparable, T any](loader func(K) (T, time.Duration, error), opts ...CacheOption) *Cache[K, T] {
+	c := &Cache[K, T]{
+		loader:  loader,
+		entries: make(map[K]*entry[K, T]),
+		recency: list.New(),
+		clock:   clock.Default(),
+	}
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case timeToLive:
+			c.ttl = time.Duration(o)
+		case negativeTimeToLive:
+			c.negativeTTL = time.Duration(o)
+		case staleWhileRevalidate:
+			c.stale = time.Duration(o)
+		case refreshAhead:
+			c.ahead = time.Duration(o)
+		case bounded:
+			c.size = o.size
+			c.eviction = o.eviction
+		}
+	}
+	return c
+}
+
+// Get return the Task for the key, which is the cached one if fresh or stale, otherwise a new load.
+//
+// A stale value or one close to expiry is served while it reloads in the background.
+func (c *Cache[K, T]) Get(key K) *Task[T] {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.clock.Now()
+	e, ok := c.entries[key]
+	switch {
+	case !ok:
+		e = c.insert(key)
+	case !e.settled:
+	case e.expiresAt.IsZero() || now.Before(e.expiresAt):
+		if c.ahead > 0 && !e.expiresAt.IsZero() && !now.Before(e.expiresAt.Add(-c.ahead)) && e.task.isSuccess() {
+			c.refresh(e)
+		}
+	case c.stale > 0 && now.Before(e.expiresAt.Add(c.stale)) && e.task.isSuccess():
+		c.refresh(e)
+	default:
+		c.remove(e)
+		e = c.insert(key)
+	}
+
+	e.hits++
+	c.recency.MoveToFront(e.element)
+	return e.task
+}
+
+// Set caches the value for the key with its own ttl kept across refreshes, never expired if zero
+func (c *Cache[K, T]) Set(key K, value T, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+	task := New[T](func() (T, error) {
+		return value, nil
+	})
+	task.complete(try.Success(value), Succeeded)
+	e := c.track(key, task)
+	e.settled = true
+	e.ttl = ttl
+	if ttl <= 0 {
+		e.ttl = -1
+	}
+	e.expiresAt = c.expiry(e)
+}
+
+// Invalidate removes the key, so the next Get loads a new value even if one is in flight
+func (c *Cache[K, T]) Invalidate(key K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// Len return the number of cached entries, including those in flight
+func (c *Cache[K, T]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.entries)
+}
+
+// insert starts loading the key into a new entry (must hold the mutex)
+func (c *Cache[K, T]) insert(key K) *entry[K, T] {
+	task, ttl := c.load(key)
+	e := c.track(key, task)
+	go c.land(e, task, ttl)
+	return e
+}
+
+// track adds the entry for the Task, evicting if full (must hold the mutex)
+func (c *Cache[K, T]) track(key K, task *Task[T]) *entry[K, T] {
+	if c.size > 0 {
+		for len(c.entries) >= c.size {
+			c.remove(c.victim())
+		}
+	}
+	e := &entry[K, T]{key: key, task: task}
+	e.element = c.recency.PushFront(e)
+	c.entries[key] = e
+	return e
+}
+
+// load runs the loader for the key in a new Task, and return where the loaded TTL is written once it settled
+func (c *Cache[K, T]) load(key K) (*Task[T], *time.Duration) {
+	ttl := new(time.Duration)
+	task := New[T](func() (T, error) {
+		res, d, err := c.loader(key)
+		*ttl = d
+		return res, err
+	}).Named("Cache")
+	task.Run()
+	return task, ttl
+}
+
+// expiry return when the entry's value is no longer fresh from now, zero if never (must hold the mutex)
+func (c *Cache[K, T]) expiry(e *entry[K, T]) time.Time {
+	ttl := e.ttl
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return c.clock.Now().Add(ttl)
+}
+
+// land sets the expiry once the Task settled, or forgets an error that is not cached
+func (c *Cache[K, T]) land(e *entry[K, T], task *Task[T], ttl *time.Duration) {
+	<-task.Done()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.entries[e.key] != e {
+		return
+	}
+	e.settled = true
+	if task.isSuccess() {
+		if *ttl != 0 {
+			e.ttl = *ttl
+		}
+		e.expiresAt = c.expiry(e)
+		return
+	}
+	if c.negativeTTL <= 0 {
+		c.remove(e)
+		return
+	}
+	e.expiresAt = c.clock.Now().Add(c.negativeTTL)
+}
+
+// refresh reloads the entry in the background, keeping the current value until the new one succeeded
+// (must hold the mutex)
+func (c *Cache[K, T]) refresh(e *entry[K, T]) {
+	if e.refreshing {
+		return
+	}
+	e.refreshing = true
+	task, ttl := c.load(e.key)
+	go func() {
+		<-task.Done()
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		e.refreshing = false
+		if c.entries[e.key] != e || !task.isSuccess() {
+			return
+		}
+		e.task = task
+		if *ttl != 0 {
+			e.ttl = *ttl
+		}
+		e.expiresAt = c.expiry(e)
+	}()
+}
+
+// victim return the entry to evict by the policy (must hold the mutex)
+func (c *Cache[K, T]) victim() *entry[K, T] {
+	if c.eviction == LFU {
+		var least *entry[K, T]
+		for el := c.recency.Back(); el != nil; el = el.Prev() {
+			e := el.Value.(*entry[K, T])
+			if least == nil || e.hits < least.hits {
+				least = e
+			}
+		}
+		return least
+	}
+	return c.recency.Back().Value.(*entry[K, T])
+}
+
+// remove deletes the entry (must hold the mutex)
+func (c *Cache[K, T]) remove(e *entry[K, T]) {
+	c.recency.Remove(e.element)
+	delete(c.entries, e.key)
+}
+
+// isSuccess indicates whether the Task settled with a value
+func (t *Task[T]) isSuccess() bool {
+	res, ok := t.Poll()
+	return ok && res.IsSuccess()
+}
@@ -0,0 +1,81 @@
+//
+//  cache_option.go
+//  task
+//
+//  Created by d-exclaimation on 9:48 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package task
+
+import "time"
+
+// Eviction is the policy for choosing which entry to remove once a Cache is full
+type Eviction int
+
+const (
+	// LRU evicts the least recently used entry
+	LRU Eviction = iota
+
+	// LFU evicts the least frequently used entry
+	LFU
+)
+
+// CacheOption is a interface pattern to be used for constructing a Cache
+type CacheOption interface {
+	// implement is a required method for allowing any settings to follow CacheOption
+	implement()
+}
+
+// timeToLive is an CacheOption for how long a value is fresh
+type timeToLive time.Duration
+
+func (t timeToLive) implement() {}
+
+// WithTTL is an CacheOption to expire values after the duration, never expired if zero
+func WithTTL(ttl time.Duration) CacheOption {
+	return timeToLive(ttl)
+}
+
+// negativeTimeToLive is an CacheOption for how long an error is cached
+type negativeTimeToLive time.Duration
+
+func (n negativeTimeToLive) implement() {}
+
+// WithNegativeTTL is an CacheOption to cache errors for the duration, not cached if zero
+func WithNegativeTTL(ttl time.Duration) CacheOption {
+	return negativeTimeToLive(ttl)
+}
+
+// staleWhileRevalidate is an CacheOption for how long an expired value is still served
+type staleWhileRevalidate time.Duration
+
+func (s staleWhileRevalidate) implement() {}
+
+// WithStaleWhileRevalidate is an CacheOption to serve an expired value for the duration while it reloads
+func WithStaleWhileRevalidate(window time.Duration) CacheOption {
+	return staleWhileRevalidate(window)
+}
+
+// refreshAhead is an CacheOption for how long before expiry a value is reloaded
+type refreshAhead time.Duration
+
+func (r refreshAhead) implement() {}
+
+// WithRefreshAhead is an CacheOption to reload a value on access within the duration before it expires
+func WithRefreshAhead(window time.Duration) CacheOption {
+	return refreshAhead(window)
+}
+
+// bounded is an CacheOption for the maximum number of entries with the eviction policy
+type bounded struct {
+	size     int
+	eviction Eviction
+}
+
+func (b bounded) implement() {}
+
+// WithMaxSize is an CacheOption to hold at most the number of entries, evicting with the policy, unbounded if zero
+func WithMaxSize(size int, eviction Eviction) CacheOption {
+	return bounded{size: size, eviction: eviction}
+}
@@ -0,0 +1,227 @@
+package task_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+// eventually fails the test if the condition is not met within a second
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+	for i := 0; i < 1000; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("condition was never met")
+}
+
+// counter is a loader that return the number of loads so far
+type counter struct {
+	loads int32
+	ttl   time.Duration
+	err   error
+}
+
+func (c *counter) load(string) (int, time.Duration, error) {
+	n := atomic.AddInt32(&c.loads, 1)
+	return int(n), c.ttl, c.err
+}
+
+func (c *counter) count() int {
+	return int(atomic.LoadInt32(&c.loads))
+}
+
+// value awaits the Task and fails the test on error
+func value(t *testing.T, tk *task.Task[int]) int {
+	t.Helper()
+	res, err := tk.Await()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestCacheSharesInFlight(t *testing.T) {
+	gate := make(chan struct{})
+	var loads int32
+	c := task.NewCache[string, int](func(string) (int, error) {
+		atomic.AddInt32(&loads, 1)
+		<-gate
+		return 1, nil
+	})
+
+	var wg sync.WaitGroup
+	tasks := make([]*task.Task[int], 10)
+	for i := range tasks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tasks[i] = c.Get("a")
+		}(i)
+	}
+	wg.Wait()
+	close(gate)
+	for _, tk := range tasks {
+		if tk != tasks[0] {
+			t.Fatal("expected every lookup to share the same Task")
+		}
+	}
+	if value(t, tasks[0]) != 1 || atomic.LoadInt32(&loads) != 1 {
+		t.Fatalf("expected a single load, got %d", loads)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := &counter{}
+	c := task.NewTimedCache[string, int](l.load, task.WithTTL(time.Minute))
+
+	value(t, c.Get("a"))
+	eventually(t, func() bool { return value(t, c.Get("a")) == 1 && l.count() == 1 })
+	vc.Advance(time.Minute)
+	if v := value(t, c.Get("a")); v != 2 {
+		t.Fatalf("expected a reload after the TTL, got %d", v)
+	}
+}
+
+func TestCacheEntryTTL(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := &counter{ttl: time.Second}
+	c := task.NewTimedCache[string, int](l.load, task.WithTTL(time.Hour), task.WithStaleWhileRevalidate(time.Hour))
+
+	value(t, c.Get("a"))
+	vc.Advance(time.Second)
+
+	// Stale after the entry's own TTL rather than the Cache's, reloaded in the background
+	if v := value(t, c.Get("a")); v != 1 {
+		t.Fatalf("expected the stale value, got %d", v)
+	}
+	eventually(t, func() bool { return value(t, c.Get("a")) == 2 })
+
+	// The refreshed entry keeps its own TTL
+	vc.Advance(time.Second)
+	value(t, c.Get("a"))
+	eventually(t, func() bool { return value(t, c.Get("a")) == 3 })
+}
+
+func TestCacheSetTTLKeptOnRefresh(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := &counter{}
+	c := task.NewTimedCache[string, int](l.load, task.WithTTL(time.Hour), task.WithStaleWhileRevalidate(time.Hour))
+
+	c.Set("a", 0, time.Second)
+	vc.Advance(time.Second)
+	value(t, c.Get("a"))
+	eventually(t, func() bool { return value(t, c.Get("a")) == 1 })
+
+	vc.Advance(time.Second)
+	value(t, c.Get("a"))
+	eventually(t, func() bool { return value(t, c.Get("a")) == 2 })
+}
+
+func TestCacheRefreshAhead(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := &counter{}
+	c := task.NewTimedCache[string, int](l.load, task.WithTTL(time.Minute), task.WithRefreshAhead(10*time.Second))
+
+	value(t, c.Get("a"))
+	vc.Advance(50 * time.Second)
+	if v := value(t, c.Get("a")); v != 1 {
+		t.Fatalf("expected the fresh value while refreshing, got %d", v)
+	}
+	eventually(t, func() bool { return value(t, c.Get("a")) == 2 })
+}
+
+func TestCacheNegative(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	l := &counter{err: errors.New("down")}
+	c := task.NewTimedCache[string, int](l.load, task.WithNegativeTTL(time.Second))
+
+	_, _ = c.Get("a").Await()
+	eventually(t, func() bool {
+		_, _ = c.Get("a").Await()
+		return l.count() == 1
+	})
+	vc.Advance(time.Second)
+	_, _ = c.Get("a").Await()
+	if l.count() != 2 {
+		t.Fatalf("expected a reload after the negative TTL, got %d loads", l.count())
+	}
+}
+
+func TestCacheErrorsNotCached(t *testing.T) {
+	l := &counter{err: errors.New("down")}
+	c := task.NewTimedCache[string, int](l.load)
+
+	_, _ = c.Get("a").Await()
+	eventually(t, func() bool {
+		_, _ = c.Get("a").Await()
+		return l.count() > 1
+	})
+}
+
+func TestCacheLRU(t *testing.T) {
+	l := &counter{}
+	c := task.NewTimedCache[string, int](l.load, task.WithMaxSize(2, task.LRU))
+
+	value(t, c.Get("a"))
+	value(t, c.Get("b"))
+	value(t, c.Get("a"))
+	value(t, c.Get("c"))
+	if c.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", c.Len())
+	}
+	loads := l.count()
+	value(t, c.Get("a"))
+	if l.count() != loads {
+		t.Fatal("expected the recently used entry to be kept")
+	}
+	value(t, c.Get("b"))
+	if l.count() != loads+1 {
+		t.Fatal("expected the least recently used entry to be evicted")
+	}
+}
+
+func TestCacheLFU(t *testing.T) {
+	l := &counter{}
+	c := task.NewTimedCache[string, int](l.load, task.WithMaxSize(2, task.LFU))
+
+	value(t, c.Get("a"))
+	value(t, c.Get("a"))
+	value(t, c.Get("b"))
+	value(t, c.Get("c"))
+	loads := l.count()
+	value(t, c.Get("a"))
+	if l.count() != loads {
+		t.Fatal("expected the frequently used entry to be kept")
+	}
+}
+
+func TestCacheSetRerun(t *testing.T) {
+	c := task.NewCache[string, int](func(string) (int, error) {
+		return 0, errors.New("not loaded")
+	})
+	c.Set("a", 5, 0)
+
+	// Rerunning a cached Task keeps the value it was set with
+	tk := c.Get("a")
+	tk.Run()
+	eventually(t, func() bool { return tk.State() != task.Running })
+	if v := value(t, c.Get("a")); v != 5 {
+		t.Fatalf("expected 5, got %d", v)
+	}
+}
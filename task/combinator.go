@@ -0,0 +1,74 @@
+//
+//  combinator.go
+//  task
+//
+//  Created by d-exclaimation on 8:31 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package task
+
+import (
+	"fmt"
+	"github.com/d-exclaimation/gocurrent/trace"
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+// All waits for every Task and return all values in order, failing with a try.MultiError of every failed Task
+// where each source is the Task's name or its index.
+func All[T any](tasks ...*Task[T]) *Task[[]T] {
+	return spawn[[]T](trace.SpanContext{}, "All", func() ([]T, error) {
+		var errs try.MultiError
+		res := make([]T, 0, len(tasks))
+		for i, t := range AllSettled(tasks...).Try().Get() {
+			value, err := t.ToOption()
+			errs.Append(source(tasks[i], i), err)
+			res = append(res, value)
+		}
+		if err := errs.ErrorOrNil(); err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// AllSettled waits for every Task and return every result in order, which never fails
+func AllSettled[T any](tasks ...*Task[T]) *Task[[]*try.Try[T]] {
+	return spawn[[]*try.Try[T]](trace.SpanContext{}, "AllSettled", func() ([]*try.Try[T], error) {
+		res := make([]*try.Try[T], 0, len(tasks))
+		for _, t := range tasks {
+			res = append(res, t.Try())
+		}
+		return res, nil
+	})
+}
+
+// Retry runs the function up to the number of attempts until it succeeds, failing with a try.MultiError of the
+// error from every attempt.
+func Retry[T any](attempts int, op func() (T, error)) *Task[T] {
+	return spawn[T](trace.SpanContext{}, "Retry", func() (T, error) {
+		var errs try.MultiError
+		for i := 0; i < attempts; i++ {
+			res, err := try.Catch[T](op).ToOption()
+			if err == nil {
+				return res, nil
+			}
+			errs.Append(fmt.Sprintf("attempt %d", i+1), err)
+		}
+		var zero T
+		if err := errs.ErrorOrNil(); err != nil {
+			return zero, err
+		}
+		return zero, ErrNoAttempts
+	})
+}
+
+// source describes the Task for a try.MultiError by its name, or its index if unnamed
+func source[T any](t *Task[T], index int) string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	if t.name != "" {
+		return t.name
+	}
+	return fmt.Sprintf("task %d", index)
+}
@@ -0,0 +1,47 @@
+package task_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/leakcheck"
+	"github.com/d-exclaimation/gocurrent/task"
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+func TestAsyncLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	tk := task.Async(func() (int, error) {
+		return 1, nil
+	})
+	_, _ = task.MapValue(tk, func(v int) int { return v + 1 }).Await()
+}
+
+func TestThenLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	done := make(chan struct{})
+	task.Async(func() (int, error) {
+		return 1, nil
+	}).Then(try.Case[int]{
+		Success: func(int) { close(done) },
+		Failure: func(error) { close(done) },
+	})
+	<-done
+}
+
+func TestTimeoutLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	gate := make(chan struct{})
+	defer close(gate)
+	_, _ = task.Timeout(task.Async(func() (int, error) {
+		<-gate
+		return 1, nil
+	}), time.Millisecond).Await()
+}
+
+func TestCancelLeak(t *testing.T) {
+	defer leakcheck.Check(t)()
+	p := task.Maybe[int]()
+	p.Task().Cancel()
+	_, _ = p.Task().Await()
+}
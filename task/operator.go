@@ -8,25 +8,45 @@
 
 package task
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"github.com/d-exclaimation/gocurrent/trace"
+	"time"
+)
+
+// ErrTimeout is the error when a Task did not complete in time
+var ErrTimeout = errors.New("task: Task did not complete in time")
+
+// ErrNoAttempts is the error when Retry is given no attempts
+var ErrNoAttempts = errors.New("task: Retry has no attempts")
 
 // Map transformed a wrapped value of a Task into a new type
-func Map[T, K any](t *Task[T], transform func(T) (K, error), base K) *Task[K] {
-	return Async[K](func() (K, error) {
+func Map[T, K any](t *Task[T], transform func(T) (K, error)) *Task[K] {
+	return spawn[K](t.SpanContext(), "Map", func() (K, error) {
 		res, err := t.Await()
 		if err != nil {
-			return base, err
+			var zero K
+			return zero, err
 		}
 		return transform(res)
 	})
 }
 
+// MapValue transformed a wrapped value of a Task into a new type with a transform that cannot fail
+func MapValue[T, K any](t *Task[T], transform func(T) K) *Task[K] {
+	return Map[T, K](t, func(res T) (K, error) {
+		return transform(res), nil
+	})
+}
+
 // FlatMap transformed a wrapped value of a Task into a Task with new type
-func FlatMap[T, K any](t *Task[T], transform func(T) *Task[K], base K) *Task[K] {
-	return Async[K](func() (K, error) {
+func FlatMap[T, K any](t *Task[T], transform func(T) *Task[K]) *Task[K] {
+	return spawn[K](t.SpanContext(), "FlatMap", func() (K, error) {
 		res, err := t.Await()
 		if err != nil {
-			return base, nil
+			var zero K
+			return zero, err
 		}
 		return transform(res).Await()
 	})
@@ -34,7 +54,7 @@ func FlatMap[T, K any](t *Task[T], transform func(T) *Task[K], base K) *Task[K]
 
 // Filter filters the wrapped value or throw an error
 func Filter[T any](r *Task[T], predicate func(T) bool) *Task[T] {
-	return Async[T](func() (T, error) {
+	return spawn[T](r.SpanContext(), "Filter", func() (T, error) {
 		data, err := r.Await()
 		if err != nil {
 			return data, err
@@ -48,7 +68,7 @@ func Filter[T any](r *Task[T], predicate func(T) bool) *Task[T] {
 
 // Recover recovers an error into the wrapped value or throw an error
 func Recover[T any](r *Task[T], recovery func(err error) (T, error)) *Task[T] {
-	return Async[T](func() (T, error) {
+	return spawn[T](r.SpanContext(), "Recover", func() (T, error) {
 		data, err := r.Await()
 		if err != nil {
 			return recovery(err)
@@ -56,3 +76,34 @@ func Recover[T any](r *Task[T], recovery func(err error) (T, error)) *Task[T] {
 		return data, nil
 	})
 }
+
+// Timeout fails with ErrTimeout if the Task did not complete within the duration on its Clock
+func Timeout[T any](t *Task[T], d time.Duration) *Task[T] {
+	deadline := t.clock.After(d)
+	return spawn[T](t.SpanContext(), "Timeout", func() (T, error) {
+		select {
+		case res := <-t.AwaitChannel():
+			return res.ToOption()
+		case <-deadline:
+			var zero T
+			return zero, ErrTimeout
+		}
+	})
+}
+
+// FlatMapContext transformed a wrapped value of a Task into a Task with new type, where the transform receives
+// a context carrying the span so the Task created with AsyncContext becomes its child
+func FlatMapContext[T, K any](t *Task[T], transform func(context.Context, T) *Task[K]) *Task[K] {
+	var task *Task[K]
+	task = child[K](t.SpanContext(), "FlatMap", func() (K, error) {
+		res, err := t.Await()
+		if err != nil {
+			var zero K
+			return zero, err
+		}
+		ctx := trace.ContextWith(context.Background(), task.SpanContext())
+		return transform(ctx, res).Await()
+	})
+	task.Run()
+	return task
+}
@@ -9,25 +9,29 @@
 package task
 
 import (
+	"errors"
 	"github.com/d-exclaimation/gocurrent/try"
 )
 
+// ErrAlreadySettled is the error when resolving a Promise more than once
+var ErrAlreadySettled = errors.New("promise: Promise has already been settled")
+
+// Promise is a Task completed manually, where only the first resolution takes effect
 type Promise[T any] struct {
-	job     *Task[T]
-	mailbox chan *try.Try[T]
+	job *Task[T]
 }
 
 // Maybe construct a new Promise
 func Maybe[T any]() *Promise[T] {
-	delivery := make(chan *try.Try[T])
-	prom := &Promise[T]{
-		job: Async[T](func() (T, error) {
-			res := <-delivery
-			return res.ToOption()
-		}),
-		mailbox: delivery,
+	job := New[T](nil)
+	job.process = func() (T, error) {
+		return job.Await()
 	}
-	return prom
+	job.state = Running
+	job.startedAt = job.clock.Now()
+	job.observer.TaskStarted(job.name)
+	job.startSpan()
+	return &Promise[T]{job: job}
 }
 
 // Task return the inner task but doesn't allow mutation
@@ -35,12 +39,40 @@ func (p *Promise[T]) Task() *Task[T] {
 	return p.job
 }
 
-// Success finishes the future with a successful value
-func (p *Promise[T]) Success(data T) {
-	p.mailbox <- try.New(data, nil)
+// TryComplete finishes the future with the Try without blocking, and return false if already settled
+func (p *Promise[T]) TryComplete(res *try.Try[T]) bool {
+	return p.job.complete(res, outcome(res))
+}
+
+// TrySuccess finishes the future with a successful value without blocking, and return false if already settled
+func (p *Promise[T]) TrySuccess(data T) bool {
+	return p.TryComplete(try.Success(data))
+}
+
+// TryFailure finishes the future with an unsuccessful value without blocking, and return false if already settled
+func (p *Promise[T]) TryFailure(err error) bool {
+	return p.TryComplete(try.Failure[T](err))
+}
+
+// Success finishes the future with a successful value without blocking, or return an error if already settled
+func (p *Promise[T]) Success(data T) error {
+	if !p.TrySuccess(data) {
+		return ErrAlreadySettled
+	}
+	return nil
+}
+
+// Failure finishes the future with an unsuccessful value without blocking, or return an error if already settled
+func (p *Promise[T]) Failure(err error) error {
+	if !p.TryFailure(err) {
+		return ErrAlreadySettled
+	}
+	return nil
 }
 
-// Failure finishes the future with an unsuccessful value (base is used for non-nullable T).
-func (p *Promise[T]) Failure(err error, base T) {
-	p.mailbox <- try.New(base, err)
+// CompleteWith finishes the future with the result of the Task once it completed, unless already settled by then
+func (p *Promise[T]) CompleteWith(t *Task[T]) {
+	go func() {
+		p.TryComplete(t.Try())
+	}()
 }
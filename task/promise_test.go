@@ -0,0 +1,75 @@
+package task_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+func TestPromiseSettleOnce(t *testing.T) {
+	p := task.Maybe[int]()
+	if err := p.Success(1); err != nil {
+		t.Fatalf("expected the first settlement to succeed, got %v", err)
+	}
+	if err := p.Success(2); !errors.Is(err, task.ErrAlreadySettled) {
+		t.Fatalf("expected ErrAlreadySettled, got %v", err)
+	}
+	if err := p.Failure(errors.New("late")); !errors.Is(err, task.ErrAlreadySettled) {
+		t.Fatalf("expected ErrAlreadySettled, got %v", err)
+	}
+	if p.TrySuccess(3) || p.TryFailure(errors.New("late")) {
+		t.Fatal("expected TrySuccess and TryFailure to report the Promise as settled")
+	}
+	if value, err := p.Task().Await(); value != 1 || err != nil {
+		t.Fatalf("expected the first value, got %v %v", value, err)
+	}
+}
+
+func TestPromiseRacingSettle(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		p := task.Maybe[int]()
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			wins []int
+		)
+		for j := 0; j < 8; j++ {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				if p.TrySuccess(j) {
+					mu.Lock()
+					wins = append(wins, j)
+					mu.Unlock()
+				}
+			}(j)
+		}
+		wg.Wait()
+		if len(wins) != 1 {
+			t.Fatalf("expected exactly one settlement, got %v", wins)
+		}
+		if value, _ := p.Task().Await(); value != wins[0] {
+			t.Fatalf("expected the winning value %d, got %d", wins[0], value)
+		}
+	}
+}
+
+func TestPromiseCancelled(t *testing.T) {
+	p := task.Maybe[int]()
+	p.Task().Cancel()
+	if err := p.Success(1); !errors.Is(err, task.ErrAlreadySettled) {
+		t.Fatalf("expected ErrAlreadySettled after Cancel, got %v", err)
+	}
+}
+
+func TestCompleteWith(t *testing.T) {
+	p := task.Maybe[int]()
+	p.CompleteWith(task.Async(func() (int, error) {
+		return 1, nil
+	}))
+	if value, err := p.Task().Await(); value != 1 || err != nil {
+		t.Fatalf("expected 1, got %v %v", value, err)
+	}
+}
@@ -0,0 +1,107 @@
+//
+//  singleflight.go
+//  task
+//
+//  Created by d-exclaimation on 9:12 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package task
+
+import (
+	"github.com/d-exclaimation/gocurrent/clock"
+	"sync"
+	"time"
+)
+
+// SingleFlight deduplicates concurrent work by key, where callers of the same key share the same Task.
+//
+//  sf := task.NewSingleFlight[string, User](0)
+//  t0 := sf.Do("user:1", fetchUser)
+//  t1 := sf.Do("user:1", fetchUser) // same Task as t0 while in flight
+type SingleFlight[K comparable, T any] struct {
+	// flights are the Tasks by key
+	flights map[K]*flight[T]
+
+	// mutex guards the flights
+	mutex sync.Mutex
+
+	// ttl is how long a successful value is kept after settling, none if zero
+	ttl time.Duration
+
+	// clock is the source of time captured from clock.Default
+	clock clock.Clock
+}
+
+// flight is a shared Task with its expiry
+type flight[T any] struct {
+	task *Task[T]
+
+	// settled indicates whether the Task has settled and kept for its ttl
+	settled bool
+
+	// expiresAt is the time the settled value is no longer shared
+	expiresAt time.Time
+}
+
+// NewSingleFlight creates a new SingleFlight that keeps successful values for the ttl after settling,
+// or shares only in-flight Tasks if the ttl is zero.
+func NewSingleFlight[K comparable, T any](ttl time.Duration) *SingleFlight[K, T] {
+	return &SingleFlight[K, T]{
+		flights: make(map[K]*flight[T]),
+		ttl:     ttl,
+		clock:   clock.Default(),
+	}
+}
+
+// Do return the Task for the key, running the function in a new Task only if there is none in flight or kept.
+func (s *SingleFlight[K, T]) Do(key K, op func() (T, error)) *Task[T] {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if f, ok := s.flights[key]; ok && (!f.settled || s.clock.Now().Before(f.expiresAt)) {
+		return f.task
+	}
+
+	f := &flight[T]{task: New[T](op).Named("SingleFlight")}
+	s.flights[key] = f
+	f.task.Run()
+	go s.land(key, f)
+	return f.task
+}
+
+// land keeps the settled value for the ttl if it succeeded, otherwise forgets it
+func (s *SingleFlight[K, T]) land(key K, f *flight[T]) {
+	<-f.task.Done()
+	res, _ := f.task.Poll()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.flights[key] != f {
+		return
+	}
+	if s.ttl <= 0 || !res.IsSuccess() {
+		delete(s.flights, key)
+		return
+	}
+	f.settled = true
+	f.expiresAt = s.clock.Now().Add(s.ttl)
+	go s.expire(key, f)
+}
+
+// expire forgets the kept value once its ttl passed, unless replaced
+func (s *SingleFlight[K, T]) expire(key K, f *flight[T]) {
+	<-s.clock.After(s.ttl)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.flights[key] == f {
+		delete(s.flights, key)
+	}
+}
+
+// Forget removes the key, so the next Do runs a new Task even if one is in flight
+func (s *SingleFlight[K, T]) Forget(key K) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.flights, key)
+}
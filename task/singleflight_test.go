@@ -0,0 +1,73 @@
+package task_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+	"github.com/d-exclaimation/gocurrent/task"
+)
+
+func TestSingleFlightShares(t *testing.T) {
+	gate := make(chan struct{})
+	var loads int32
+	op := func() (int, error) {
+		atomic.AddInt32(&loads, 1)
+		<-gate
+		return 7, nil
+	}
+	sf := task.NewSingleFlight[string, int](0)
+
+	first := sf.Do("k", op)
+	second := sf.Do("k", op)
+	if first != second {
+		t.Fatal("expected concurrent callers to share the Task")
+	}
+	doubled := task.MapValue(first, func(v int) int { return v * 2 })
+	close(gate)
+	if value(t, doubled) != 14 || atomic.LoadInt32(&loads) != 1 {
+		t.Fatalf("expected a single run, got %d", loads)
+	}
+
+	// Without a TTL, a settled Task is not shared
+	eventually(t, func() bool { return sf.Do("k", op) != first })
+}
+
+func TestSingleFlightTTL(t *testing.T) {
+	vc := clocktest.NewVirtual(time.Unix(0, 0))
+	defer clock.SetDefault(vc)()
+	var loads int32
+	op := func() (int, error) {
+		return int(atomic.AddInt32(&loads, 1)), nil
+	}
+	sf := task.NewSingleFlight[string, int](time.Minute)
+
+	first := sf.Do("k", op)
+	value(t, first)
+	vc.BlockUntil(1)
+	if sf.Do("k", op) != first {
+		t.Fatal("expected the value to be kept within the TTL")
+	}
+	vc.Advance(time.Minute)
+	if sf.Do("k", op) == first {
+		t.Fatal("expected the value to expire after the TTL")
+	}
+}
+
+func TestSingleFlightForget(t *testing.T) {
+	gate := make(chan struct{})
+	defer close(gate)
+	op := func() (int, error) {
+		<-gate
+		return 1, nil
+	}
+	sf := task.NewSingleFlight[string, int](0)
+
+	first := sf.Do("k", op)
+	sf.Forget("k")
+	if sf.Do("k", op) == first {
+		t.Fatal("expected a new Task after Forget")
+	}
+}
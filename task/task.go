@@ -9,10 +9,57 @@
 package task
 
 import (
+	"context"
+	"errors"
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/observe"
+	"github.com/d-exclaimation/gocurrent/trace"
 	"github.com/d-exclaimation/gocurrent/try"
+	"sync"
 	"time"
 )
 
+// ErrCancelled is the error when a Task has been cancelled before it completed
+var ErrCancelled = errors.New("task: Task has been cancelled")
+
+// State is the lifecycle state of a Task
+type State int
+
+const (
+	// Pending is when a Task has not been run
+	Pending State = iota
+
+	// Running is when a Task is acquiring its value
+	Running
+
+	// Succeeded is when a Task acquired a value
+	Succeeded
+
+	// Failed is when a Task acquired an error
+	Failed
+
+	// Cancelled is when a Task has been cancelled before it completed
+	Cancelled
+)
+
+// String return the name of the state
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Running:
+		return "running"
+	case Succeeded:
+		return "succeeded"
+	case Failed:
+		return "failed"
+	case Cancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
 // Task is  unit of asynchronous work
 //
 // Example code:
@@ -29,6 +76,8 @@ import (
 //        }
 //        return nil
 //    }
+//
+// A Task only holds a goroutine while its function is running, so it needs no teardown.
 type Task[T any] struct {
 	// Wrapped value in a Try of the Task
 	value *try.Try[T]
@@ -36,25 +85,66 @@ type Task[T any] struct {
 	process func() (T, error)
 	// The channels that requested for the awaited value
 	deliveries map[chan<- *try.Try[T]]<-chan *try.Try[T]
-	// Channel for sending newly acquired value
-	mailbox chan *try.Try[T]
-	// Channel for sending new awaiter
-	delivery chan chan *try.Try[T]
+	// Channel closed once the first value acquired
+	done chan struct{}
+	// Guard for the value, deliveries, state, and timestamps
+	mutex sync.RWMutex
+	// The lifecycle state of the latest run
+	state State
+	// The time the latest run started
+	startedAt time.Time
+	// The time the latest run finished
+	finishedAt time.Time
+	// The source of time captured from clock.Default
+	clock clock.Clock
+	// The metrics observer captured from observe.Default
+	observer observe.Observer
+	// The identifier for the observer and tracer
+	name string
+	// The tracer captured from trace.Default
+	tracer trace.Tracer
+	// The span of the latest run
+	span trace.Span
+	// The number of runs started
+	runs int
 }
 
 // New creates a new Task but does not run it.
 //
 // Running will be delegated to the caller by running task.Run or task.LazyAwait.
 func New[T any](op func() (T, error)) *Task[T] {
-	task := &Task[T]{
+	return child[T](trace.SpanContext{}, "", op)
+}
+
+// NewContext creates a new Task as a child span of the context's span but does not run it.
+//
+// The function receives the context carrying the Task's own span, so Tasks created within become its children.
+func NewContext[T any](ctx context.Context, op func(context.Context) (T, error)) *Task[T] {
+	var task *Task[T]
+	task = child[T](trace.FromContext(ctx), "", func() (T, error) {
+		return op(trace.ContextWith(ctx, task.SpanContext()))
+	})
+	return task
+}
+
+// child creates a new Task with the parent span and name
+func child[T any](parent trace.SpanContext, name string, op func() (T, error)) *Task[T] {
+	return &Task[T]{
 		value:      nil,
 		process:    op,
-		mailbox:    make(chan *try.Try[T]),
 		deliveries: make(map[chan<- *try.Try[T]]<-chan *try.Try[T]),
-		delivery:   make(chan chan *try.Try[T]),
+		done:       make(chan struct{}),
+		state:      Pending,
+		clock:      clock.Default(),
+		observer:   observe.Default(),
+		name:       name,
+		tracer:     trace.Default(),
+		span: trace.Span{
+			Name:    name,
+			Context: trace.Child(parent),
+			Parent:  parent,
+		},
 	}
-	task.behavior()
-	return task
 }
 
 // Async creates a new Task and run it immediately.
@@ -64,6 +154,20 @@ func Async[T any](op func() (T, error)) *Task[T] {
 	return task
 }
 
+// AsyncContext creates a new Task as a child span of the context's span and run it immediately.
+func AsyncContext[T any](ctx context.Context, op func(context.Context) (T, error)) *Task[T] {
+	task := NewContext[T](ctx, op)
+	task.Run()
+	return task
+}
+
+// spawn creates a new Task as a child span of the parent and run it immediately.
+func spawn[T any](parent trace.SpanContext, name string, op func() (T, error)) *Task[T] {
+	task := child[T](parent, name, op)
+	task.Run()
+	return task
+}
+
 // AsyncVoid run a non-returning function in a Task
 func AsyncVoid(op func() error) {
 	New[struct{}](func() (struct{}, error) {
@@ -73,45 +177,97 @@ func AsyncVoid(op func() error) {
 
 // Run the Task, if already run before it will retry running but does not reset state
 // i.e. the previous value will not be cleared until the new value acquired.
+// A panic in the function fails the Task with a try.PanicError.
 func (t *Task[T]) Run() {
-	go func() {
-		t.mailbox <- try.From[T](t.process)
-	}()
-}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.state == Cancelled {
+		return
+	}
+	t.state = Running
+	t.startedAt = t.clock.Now()
+	t.finishedAt = time.Time{}
+	t.observer.TaskStarted(t.name)
+	t.startSpan()
 
-// Actor like behavior
-func (t *Task[T]) behavior() {
 	go func() {
-		for {
-			select {
-			case deliver, valid := <-t.delivery:
-				if !valid {
-					continue
-				}
-				if t.value != nil {
-					deliver <- t.value
-					close(deliver)
-				} else {
-					t.deliveries[deliver] = deliver
-				}
-
-			case res, valid := <-t.mailbox:
-				if !valid {
-					continue
-				}
-				t.value = res
-				if res != nil {
-					for in, _ := range t.deliveries {
-						in <- res
-						close(in)
-						delete(t.deliveries, in)
-					}
-				}
-			}
+		res := try.Catch[T](t.process)
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		if t.state == Cancelled {
+			return
 		}
+		t.settle(res, outcome(res))
 	}()
 }
 
+// complete settles the Task with the value unless it already completed, and return false if it did
+func (t *Task[T]) complete(res *try.Try[T], state State) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.state == Succeeded || t.state == Failed || t.state == Cancelled {
+		return false
+	}
+	t.settle(res, state)
+	return true
+}
+
+// settle stores the acquired value with its state and deliver it to all awaiters (must hold the mutex)
+func (t *Task[T]) settle(res *try.Try[T], state State) {
+	started := t.state == Running
+	t.value = res
+	t.state = state
+	t.finishedAt = t.clock.Now()
+	if started {
+		_, err := res.ToOption()
+		t.observer.TaskSettled(t.name, t.finishedAt.Sub(t.startedAt), err)
+		t.span.End = t.finishedAt
+		t.span.Err = err
+		t.tracer.SpanEnded(t.span)
+	}
+	for in := range t.deliveries {
+		in <- res
+		close(in)
+		delete(t.deliveries, in)
+	}
+	select {
+	case <-t.done:
+	default:
+		close(t.done)
+	}
+}
+
+// startSpan begins the span for a new run, with a new identity for retries (must hold the mutex)
+func (t *Task[T]) startSpan() {
+	if t.runs > 0 {
+		t.span.Context = trace.Child(t.span.Parent)
+	}
+	t.runs++
+	t.span.Name = t.name
+	if t.span.Name == "" {
+		t.span.Name = "task"
+	}
+	t.span.Start = t.startedAt
+	t.span.End = time.Time{}
+	t.span.Err = nil
+	t.tracer.SpanStarted(t.span)
+}
+
+// outcome return the final state for the acquired value
+func outcome[T any](res *try.Try[T]) State {
+	if res.IsSuccess() {
+		return Succeeded
+	}
+	return Failed
+}
+
+// Cancel settles the Task with ErrCancelled unless it already completed, and return false if it did.
+//
+// Note: The running function is not interrupted, its value will just be ignored.
+func (t *Task[T]) Cancel() bool {
+	return t.complete(try.Failure[T](ErrCancelled), Cancelled)
+}
+
 // LazyAwait run the Task and waits for the returned value.
 // Important:
 //  - This will not work for retries
@@ -129,16 +285,23 @@ func (t *Task[T]) Await() (T, error) {
 
 // AwaitChannel returns the consuming channel for awaiting the asynchronous value.
 func (t *Task[T]) AwaitChannel() <-chan *try.Try[T] {
-	deliver := make(chan *try.Try[T])
-	go func() {
-		time.Sleep(time.Millisecond)
-		t.delivery <- deliver
-	}()
+	deliver := make(chan *try.Try[T], 1)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.value != nil {
+		deliver <- t.value
+		close(deliver)
+	} else {
+		t.deliveries[deliver] = deliver
+	}
 	return deliver
 }
 
 // Try awaits for the asynchronous value but return them in a Try.
 func (t *Task[T]) Try() *try.Try[T] {
+	if res, ok := t.Poll(); ok {
+		return res
+	}
 	deliver := t.AwaitChannel()
 	return <-deliver
 }
@@ -149,3 +312,53 @@ func (t *Task[T]) Then(matcher try.Case[T]) {
 		t.Try().Match(matcher)
 	}()
 }
+
+// Named sets the identifier of the Task for the Observer, and return the Task for chaining.
+//
+// Note: Name the Task before running it, so the start is reported with the name.
+func (t *Task[T]) Named(name string) *Task[T] {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.name = name
+	return t
+}
+
+// SpanContext return the identity of the Task's span for the latest run
+func (t *Task[T]) SpanContext() trace.SpanContext {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.span.Context
+}
+
+// State return the lifecycle state of the latest run
+func (t *Task[T]) State() State {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.state
+}
+
+// Poll return the acquired value without waiting, and false if there is none yet
+func (t *Task[T]) Poll() (*try.Try[T], bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.value, t.value != nil
+}
+
+// Done returns a channel that is closed once the first value acquired
+func (t *Task[T]) Done() <-chan struct{} {
+	return t.done
+}
+
+// StartedAt return the time the latest run started, zero if never run
+func (t *Task[T]) StartedAt() time.Time {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.startedAt
+}
+
+// FinishedAt return the time the latest run finished, zero if still pending or running
+func (t *Task[T]) FinishedAt() time.Time {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.finishedAt
+}
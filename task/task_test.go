@@ -0,0 +1,180 @@
+package task_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/clock"
+	"github.com/d-exclaimation/gocurrent/clock/clocktest"
+	"github.com/d-exclaimation/gocurrent/task"
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+func TestAsyncPanicNil(t *testing.T) {
+	_, err := task.Async[int](func() (int, error) {
+		panic(nil)
+	}).Await()
+	var pe *try.PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected a PanicError, got %v", err)
+	}
+}
+
+func TestAwaitRacingSettle(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		p := task.Maybe[int]()
+		tk := p.Task()
+		var wg sync.WaitGroup
+		for j := 0; j < 8; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if value, err := tk.Await(); value != 1 || err != nil {
+					t.Errorf("expected 1, got %v %v", value, err)
+				}
+			}()
+		}
+		go p.TrySuccess(1)
+		wg.Wait()
+	}
+}
+
+func TestAwaitChannelAfterSettle(t *testing.T) {
+	tk := task.Async(func() (int, error) {
+		return 1, nil
+	})
+	<-tk.Done()
+	for i := 0; i < 2; i++ {
+		if res := <-tk.AwaitChannel(); res.OrElse(0) != 1 {
+			t.Fatalf("expected 1, got %v", res)
+		}
+	}
+}
+
+func TestAwaitChannelRacingSettle(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		p := task.Maybe[int]()
+		go p.TrySuccess(1)
+		if res := <-p.Task().AwaitChannel(); res.OrElse(0) != 1 {
+			t.Fatalf("expected 1, got %v", res)
+		}
+	}
+}
+
+func TestStateAndTimestamps(t *testing.T) {
+	start := time.Unix(0, 0)
+	vc := clocktest.NewVirtual(start)
+	defer clock.SetDefault(vc)()
+
+	gate := make(chan int)
+	tk := task.New(func() (int, error) {
+		return <-gate, nil
+	})
+	if tk.State() != task.Pending || !tk.StartedAt().IsZero() || !tk.FinishedAt().IsZero() {
+		t.Fatalf("expected a pending Task without timestamps, got %v", tk.State())
+	}
+	if _, ok := tk.Poll(); ok {
+		t.Fatal("expected no value before running")
+	}
+
+	tk.Run()
+	if tk.State() != task.Running || !tk.StartedAt().Equal(start) {
+		t.Fatalf("expected running since the start, got %v %v", tk.State(), tk.StartedAt())
+	}
+	vc.Advance(time.Second)
+	gate <- 1
+	<-tk.Done()
+	if tk.State() != task.Succeeded || !tk.FinishedAt().Equal(start.Add(time.Second)) {
+		t.Fatalf("expected succeeded after a second, got %v %v", tk.State(), tk.FinishedAt())
+	}
+
+	// A rerun is running again without a finish time, while still serving the previous value
+	vc.Advance(time.Second)
+	tk.Run()
+	if tk.State() != task.Running || !tk.FinishedAt().IsZero() || !tk.StartedAt().Equal(start.Add(2*time.Second)) {
+		t.Fatalf("expected a fresh run, got %v %v %v", tk.State(), tk.StartedAt(), tk.FinishedAt())
+	}
+	if res, ok := tk.Poll(); !ok || res.OrElse(0) != 1 {
+		t.Fatalf("expected the previous value while rerunning, got %v", res)
+	}
+	gate <- 2
+}
+
+func TestStateFailedAndCancelled(t *testing.T) {
+	failed := task.Async(func() (int, error) {
+		return 0, errors.New("failed")
+	})
+	<-failed.Done()
+	if failed.State() != task.Failed {
+		t.Fatalf("expected failed, got %v", failed.State())
+	}
+
+	p := task.Maybe[int]()
+	p.Task().Cancel()
+	if _, err := p.Task().Await(); p.Task().State() != task.Cancelled || !errors.Is(err, task.ErrCancelled) {
+		t.Fatalf("expected cancelled, got %v %v", p.Task().State(), err)
+	}
+}
+
+func TestMapValue(t *testing.T) {
+	res, err := task.MapValue(task.Async(func() (int, error) {
+		return 2, nil
+	}), func(v int) string {
+		return strings.Repeat("a", v)
+	}).Await()
+	if res != "aa" || err != nil {
+		t.Fatalf("expected aa, got %q %v", res, err)
+	}
+}
+
+func TestFlatMapFailedSource(t *testing.T) {
+	errSource := errors.New("source")
+	called := false
+	res, err := task.FlatMap(task.Async(func() (int, error) {
+		return 1, errSource
+	}), func(v int) *task.Task[int] {
+		called = true
+		return task.Async(func() (int, error) {
+			return v, nil
+		})
+	}).Await()
+	if !errors.Is(err, errSource) || res != 0 || called {
+		t.Fatalf("expected the source error without calling the transform, got %v %v", res, err)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	errInner := errors.New("inner")
+	source := task.Async(func() (int, error) {
+		return 1, nil
+	})
+	res, err := task.FlatMap(source, func(v int) *task.Task[int] {
+		return task.Async(func() (int, error) {
+			return v + 1, nil
+		})
+	}).Await()
+	if res != 2 || err != nil {
+		t.Fatalf("expected 2, got %v %v", res, err)
+	}
+	if _, err := task.FlatMap(source, func(int) *task.Task[int] {
+		p := task.Maybe[int]()
+		_ = p.Failure(errInner)
+		return p.Task()
+	}).Await(); !errors.Is(err, errInner) {
+		t.Fatalf("expected the inner error, got %v", err)
+	}
+}
+
+func TestPromiseFailure(t *testing.T) {
+	errFailure := errors.New("failure")
+	p := task.Maybe[int]()
+	if err := p.Failure(errFailure); err != nil {
+		t.Fatalf("expected the first settlement to succeed, got %v", err)
+	}
+	if res, err := p.Task().Await(); res != 0 || !errors.Is(err, errFailure) {
+		t.Fatalf("expected the failure, got %v %v", res, err)
+	}
+}
@@ -0,0 +1,247 @@
+//
+//  otlp.go
+//  trace
+//
+//  Created by d-exclaimation on 2:40 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package trace
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// OTLP is a Tracer that writes each ended span as a line of OpenTelemetry protocol JSON (an ExportTraceServiceRequest),
+// the format read by the OpenTelemetry Collector's file receiver.
+//
+// Spans are written in order from a separate goroutine, so a slow writer never blocks any Task.
+type OTLP struct {
+	// mutex guards the pending lines and the writing state
+	mutex sync.Mutex
+
+	// idle is signalled once every pending line has been written
+	idle *sync.Cond
+
+	// pending are the lines not yet written, in order
+	pending [][]byte
+
+	// writing indicates whether the writer goroutine is writing a batch
+	writing bool
+
+	// closed indicates whether the Tracer is closed and ignore new spans
+	closed bool
+
+	// wake signals the writer goroutine that there are pending lines
+	wake chan struct{}
+
+	// stop is closed once the Tracer is closed with Close
+	stop chan struct{}
+
+	// stopping guards closing the stop channel
+	stopping sync.Once
+
+	// writer is the destination
+	writer io.Writer
+
+	// service is the service.name resource attribute
+	service string
+}
+
+// NewOTLP instantiate a new OTLP Tracer writing to the writer for the service name.
+//
+// The Tracer writes from a goroutine that only exits once it is closed with Close.
+func NewOTLP(w io.Writer, service string) *OTLP {
+	o := &OTLP{
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		writer:  w,
+		service: service,
+	}
+	o.idle = sync.NewCond(&o.mutex)
+	go o.run()
+	return o
+}
+
+// OTLPFile is an OTLP Tracer writing to a local file
+type OTLPFile struct {
+	*OTLP
+	file *os.File
+}
+
+// CreateOTLPFile creates or truncates the file and return an OTLP Tracer writing to it
+func CreateOTLPFile(path string, service string) (*OTLPFile, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &OTLPFile{
+		OTLP: NewOTLP(file, service),
+		file: file,
+	}, nil
+}
+
+// Close writes every pending span and closes the underlying file
+func (f *OTLPFile) Close() error {
+	f.OTLP.Close()
+	return f.file.Close()
+}
+
+func (o *OTLP) SpanStarted(Span) {}
+
+func (o *OTLP) SpanEnded(span Span) {
+	line, err := json.Marshal(o.request(span))
+	if err != nil {
+		return
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.closed {
+		return
+	}
+	o.pending = append(o.pending, append(line, '\n'))
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Flush waits until every span ended so far has been written
+func (o *OTLP) Flush() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for len(o.pending) > 0 || o.writing {
+		o.idle.Wait()
+	}
+}
+
+// Close writes every pending span and stops the writer goroutine, ignoring spans ended afterwards
+func (o *OTLP) Close() {
+	o.mutex.Lock()
+	o.closed = true
+	o.mutex.Unlock()
+	o.stopping.Do(func() {
+		close(o.stop)
+	})
+	o.Flush()
+}
+
+// run writes pending lines in order until the Tracer is closed
+func (o *OTLP) run() {
+	for {
+		select {
+		case <-o.wake:
+			o.write()
+		case <-o.stop:
+			o.write()
+			return
+		}
+	}
+}
+
+// write writes the lines pending so far
+func (o *OTLP) write() {
+	o.mutex.Lock()
+	batch := o.pending
+	o.pending = nil
+	o.writing = true
+	o.mutex.Unlock()
+
+	for _, line := range batch {
+		_, _ = o.writer.Write(line)
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.writing = false
+	if len(o.pending) == 0 {
+		o.idle.Broadcast()
+	}
+}
+
+// request builds the ExportTraceServiceRequest for a single span
+func (o *OTLP) request(span Span) otlpRequest {
+	res := otlpSpan{
+		TraceID:           span.Context.TraceID.String(),
+		SpanID:            span.Context.SpanID.String(),
+		Name:              span.Name,
+		Kind:              1,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Status:            otlpStatus{Code: 1},
+	}
+	if span.Parent.IsValid() {
+		res.ParentSpanID = span.Parent.SpanID.String()
+	}
+	if span.Err != nil {
+		res.Status = otlpStatus{Code: 2, Message: span.Err.Error()}
+	}
+
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{{
+					Key:   "service.name",
+					Value: otlpValue{StringValue: o.service},
+				}},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/d-exclaimation/gocurrent"},
+				Spans: []otlpSpan{res},
+			}},
+		}},
+	}
+}
+
+// --- OTLP JSON encoding ---
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Status            otlpStatus `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,122 @@
+package trace_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d-exclaimation/gocurrent/trace"
+)
+
+// exported is the subset of an ExportTraceServiceRequest the tests check
+type exported struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []struct {
+				Key   string `json:"key"`
+				Value struct {
+					StringValue string `json:"stringValue"`
+				} `json:"value"`
+			} `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID           string `json:"traceId"`
+				SpanID            string `json:"spanId"`
+				ParentSpanID      string `json:"parentSpanId"`
+				Name              string `json:"name"`
+				StartTimeUnixNano string `json:"startTimeUnixNano"`
+				EndTimeUnixNano   string `json:"endTimeUnixNano"`
+				Status            struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func TestOTLPShape(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := trace.NewOTLP(&buf, "orders")
+
+	parent := trace.Child(trace.SpanContext{})
+	child := trace.Child(parent)
+	start := time.Unix(1, 0)
+	tracer.SpanEnded(trace.Span{Name: "Root", Context: parent, Start: start, End: start.Add(time.Second)})
+	tracer.SpanEnded(trace.Span{Name: "Child", Context: child, Parent: parent, Start: start, End: start, Err: errors.New("boom")})
+	tracer.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a line per span, got %q", buf.String())
+	}
+	var reqs [2]exported
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &reqs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource := reqs[0].ResourceSpans[0].Resource.Attributes[0]
+	if resource.Key != "service.name" || resource.Value.StringValue != "orders" {
+		t.Fatalf("expected the service name, got %+v", resource)
+	}
+	root := reqs[0].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if root.Name != "Root" || root.TraceID != parent.TraceID.String() || root.SpanID != parent.SpanID.String() {
+		t.Fatalf("expected the root identity, got %+v", root)
+	}
+	if root.ParentSpanID != "" || root.Status.Code != 1 {
+		t.Fatalf("expected an ok root without parent, got %+v", root)
+	}
+	if root.StartTimeUnixNano != "1000000000" || root.EndTimeUnixNano != "2000000000" {
+		t.Fatalf("expected nanosecond timestamps as strings, got %+v", root)
+	}
+
+	span := reqs[1].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if span.TraceID != parent.TraceID.String() || span.ParentSpanID != parent.SpanID.String() {
+		t.Fatalf("expected the child in the parent's trace, got %+v", span)
+	}
+	if span.Status.Code != 2 || span.Status.Message != "boom" {
+		t.Fatalf("expected an error status, got %+v", span.Status)
+	}
+}
+
+// blocked is a writer that blocks until released
+type blocked struct {
+	release chan struct{}
+	buf     bytes.Buffer
+}
+
+func (b *blocked) Write(p []byte) (int, error) {
+	<-b.release
+	return b.buf.Write(p)
+}
+
+func TestOTLPDoesNotBlock(t *testing.T) {
+	w := &blocked{release: make(chan struct{})}
+	tracer := trace.NewOTLP(w, "orders")
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			tracer.SpanEnded(trace.Span{Name: "Task", Context: trace.Child(trace.SpanContext{})})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SpanEnded blocked on the writer")
+	}
+
+	close(w.release)
+	tracer.Flush()
+	if n := strings.Count(w.buf.String(), "\n"); n != 10 {
+		t.Fatalf("expected every span written after Flush, got %d", n)
+	}
+	tracer.Close()
+}
@@ -0,0 +1,151 @@
+//
+//  trace.go
+//  trace
+//
+//  Created by d-exclaimation on 2:06 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package trace
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// TraceID is the identifier shared by every span of a trace
+type TraceID [16]byte
+
+// String return the hex representation
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID is the identifier of a single span
+type SpanID [8]byte
+
+// String return the hex representation
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext is the identity of a span propagated to its children
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid indicates whether the SpanContext identifies a span
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Child return a new SpanContext in the same trace as the parent, or in a new trace if the parent is not valid
+func Child(parent SpanContext) SpanContext {
+	res := SpanContext{TraceID: parent.TraceID}
+	if !parent.IsValid() {
+		binary.BigEndian.PutUint64(res.TraceID[:8], random())
+		binary.BigEndian.PutUint64(res.TraceID[8:], random())
+	}
+	binary.BigEndian.PutUint64(res.SpanID[:], random())
+	return res
+}
+
+// random return a non-zero random number
+func random() uint64 {
+	for {
+		if n := rand.Uint64(); n != 0 {
+			return n
+		}
+	}
+}
+
+// Span is a unit of work in a trace
+type Span struct {
+	// Name is the operation name
+	Name string
+
+	// Context is the identity of the span
+	Context SpanContext
+
+	// Parent is the identity of the parent span, not valid for root spans
+	Parent SpanContext
+
+	// Start is when the work started
+	Start time.Time
+
+	// End is when the work finished, zero if still running
+	End time.Time
+
+	// Err is the failure of the work if any
+	Err error
+}
+
+// Tracer is notified when spans start and end, which must not block
+type Tracer interface {
+	// SpanStarted is called when a span starts
+	SpanStarted(span Span)
+
+	// SpanEnded is called when a span ends
+	SpanEnded(span Span)
+}
+
+// nop is the Tracer that ignores everything
+type nop struct{}
+
+func (n nop) SpanStarted(Span) {}
+func (n nop) SpanEnded(Span)   {}
+
+// Nop return the Tracer that ignores everything
+func Nop() Tracer {
+	return nop{}
+}
+
+var (
+	// mutex guards the current default
+	mutex sync.RWMutex
+
+	// current is the default Tracer
+	current Tracer = nop{}
+)
+
+// Default return the Tracer captured by every new Task
+func Default() Tracer {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
+
+// SetDefault replaces the Tracer captured by every new Task, and return a function to restore the previous one.
+func SetDefault(t Tracer) func() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	prev := current
+	current = t
+	return func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		current = prev
+	}
+}
+
+// key is the context key for the SpanContext
+type key struct{}
+
+// ContextWith return a copy of the context carrying the SpanContext
+func ContextWith(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, key{}, sc)
+}
+
+// FromContext return the SpanContext carried by the context, not valid if there is none
+func FromContext(ctx context.Context) SpanContext {
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(key{}).(SpanContext)
+	return sc
+}
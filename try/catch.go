@@ -0,0 +1,64 @@
+//
+//  catch.go
+//  try
+//
+//  Created by d-exclaimation on 5:02 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package try
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the failure of a Try whose function panicked
+type PanicError struct {
+	// Value is the recovered value
+	Value interface{}
+
+	// Stack is the stack trace of the panicking goroutine
+	Stack []byte
+}
+
+// Error return the recovered value as message
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("try: Function panicked with %v", p.Value)
+}
+
+// Unwrap return the recovered value if it is an error
+func (p *PanicError) Unwrap() error {
+	if err, ok := p.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Catch instantiate a new Try from throwing function, converting a panic into a failure with a PanicError
+//
+// Note: A panic(nil) is still a failure, with a nil Value
+func Catch[T any](op func() (T, error)) (res *Try[T]) {
+	returned := false
+	defer func() {
+		r := recover()
+		if returned {
+			return
+		}
+		res = Failure[T](&PanicError{
+			Value: r,
+			Stack: debug.Stack(),
+		})
+	}()
+	res = From[T](op)
+	returned = true
+	return res
+}
+
+// Must return the value, or panic with the error if there is one
+func Must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
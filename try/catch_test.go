@@ -0,0 +1,40 @@
+package try_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+func TestCatchPanicNil(t *testing.T) {
+	res := try.Catch[int](func() (int, error) {
+		panic(nil)
+	})
+	if res == nil || res.IsSuccess() {
+		t.Fatalf("expected a failure, got %v", res)
+	}
+	var pe *try.PanicError
+	if !errors.As(res.Err(), &pe) || pe.Value != nil {
+		t.Fatalf("expected a PanicError with nil value, got %v", res.Err())
+	}
+}
+
+func TestCatchPanic(t *testing.T) {
+	cause := errors.New("boom")
+	res := try.Catch[int](func() (int, error) {
+		panic(cause)
+	})
+	if !errors.Is(res.Err(), cause) {
+		t.Fatalf("expected the panic value to unwrap, got %v", res.Err())
+	}
+}
+
+func TestCatchReturn(t *testing.T) {
+	value, err := try.Catch[int](func() (int, error) {
+		return 1, nil
+	}).ToOption()
+	if value != 1 || err != nil {
+		t.Fatalf("expected 1, got %v %v", value, err)
+	}
+}
@@ -0,0 +1,116 @@
+//
+//  encoding.go
+//  try
+//
+//  Created by d-exclaimation on 7:02 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package try
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// encodedTry is the JSON shape of a Try, either {"value": ...} or {"error": {"message": ..., "type": ...}}
+type encodedTry[T any] struct {
+	Value *T            `json:"value,omitempty"`
+	Error *encodedError `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes the Try as {"value": ...} if succeeded, or {"error": {"message": ..., "type": ...}} if failed
+func (try *Try[T]) MarshalJSON() ([]byte, error) {
+	if try.IsSuccess() {
+		return json.Marshal(struct {
+			Value T `json:"value"`
+		}{try.value})
+	}
+	enc, err := encodeError(try.error)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encodedTry[T]{Error: &enc})
+}
+
+// UnmarshalJSON decodes the Try, reconstructing registered error types
+func (try *Try[T]) UnmarshalJSON(data []byte) error {
+	var enc encodedTry[T]
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+	return try.decode(enc)
+}
+
+// decode sets the Try from its encoding
+func (try *Try[T]) decode(enc encodedTry[T]) error {
+	var zero T
+	try.value, try.error = zero, nil
+	if enc.Error != nil {
+		err, e := decodeError(*enc.Error)
+		if e != nil {
+			return e
+		}
+		try.error = err
+		return nil
+	}
+	if enc.Value != nil {
+		try.value = *enc.Value
+	}
+	return nil
+}
+
+// MarshalText encodes the Try as its JSON representation
+func (try *Try[T]) MarshalText() ([]byte, error) {
+	return try.MarshalJSON()
+}
+
+// UnmarshalText decodes the Try from its JSON representation
+func (try *Try[T]) UnmarshalText(text []byte) error {
+	return try.UnmarshalJSON(text)
+}
+
+// gobTry is the gob shape of a Try
+type gobTry[T any] struct {
+	Value  T
+	Failed bool
+	Error  encodedError
+}
+
+// GobEncode encodes the Try with gob, where the error is described like MarshalJSON
+func (try *Try[T]) GobEncode() ([]byte, error) {
+	res := gobTry[T]{Value: try.value}
+	if !try.IsSuccess() {
+		enc, err := encodeError(try.error)
+		if err != nil {
+			return nil, err
+		}
+		res.Failed = true
+		res.Error = enc
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(res); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes the Try from gob, reconstructing registered error types
+func (try *Try[T]) GobDecode(data []byte) error {
+	var res gobTry[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&res); err != nil {
+		return err
+	}
+	if !res.Failed {
+		try.value, try.error = res.Value, nil
+		return nil
+	}
+	err, e := decodeError(res.Error)
+	if e != nil {
+		return e
+	}
+	var zero T
+	try.value, try.error = zero, err
+	return nil
+}
@@ -0,0 +1,101 @@
+package try_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+var errNotFound = errors.New("not found")
+
+type validationError struct {
+	Field string `json:"field"`
+}
+
+func (v *validationError) Error() string {
+	return "invalid " + v.Field
+}
+
+// uncomparable is an error whose dynamic type cannot be compared with ==
+type uncomparable []string
+
+func (u uncomparable) Error() string {
+	return "uncomparable"
+}
+
+func init() {
+	try.RegisterSentinel("not_found", errNotFound)
+	try.RegisterError[*validationError]("validation")
+}
+
+// roundTrip encodes and decodes the Try as JSON
+func roundTrip(t *testing.T, res *try.Try[int]) *try.Try[int] {
+	t.Helper()
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded try.Try[int]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return &decoded
+}
+
+func TestJSONSuccess(t *testing.T) {
+	data, _ := json.Marshal(try.Success(3))
+	if string(data) != `{"value":3}` {
+		t.Fatalf("unexpected encoding %s", data)
+	}
+	if value, err := roundTrip(t, try.Success(3)).ToOption(); value != 3 || err != nil {
+		t.Fatalf("expected 3, got %v %v", value, err)
+	}
+}
+
+func TestJSONSentinel(t *testing.T) {
+	if err := roundTrip(t, try.Failure[int](errNotFound)).Err(); err != errNotFound {
+		t.Fatalf("expected the sentinel, got %#v", err)
+	}
+}
+
+func TestJSONWrappedSentinel(t *testing.T) {
+	err := roundTrip(t, try.Failure[int](fmt.Errorf("load: %w", errNotFound))).Err()
+	if !errors.Is(err, errNotFound) || err.Error() != "load: not found" {
+		t.Fatalf("expected the wrapped sentinel, got %#v", err)
+	}
+}
+
+func TestJSONTyped(t *testing.T) {
+	err := roundTrip(t, try.Failure[int](fmt.Errorf("save: %w", &validationError{Field: "name"}))).Err()
+	var ve *validationError
+	if !errors.As(err, &ve) || ve.Field != "name" || err.Error() != "save: invalid name" {
+		t.Fatalf("expected the wrapped validation error, got %#v", err)
+	}
+}
+
+func TestJSONUncomparable(t *testing.T) {
+	err := roundTrip(t, try.Failure[int](uncomparable{"a"})).Err()
+	var remote *try.RemoteError
+	if !errors.As(err, &remote) || remote.Message != "uncomparable" {
+		t.Fatalf("expected a RemoteError, got %#v", err)
+	}
+}
+
+func TestGob(t *testing.T) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(try.Failure[int](errNotFound)); err != nil {
+		t.Fatal(err)
+	}
+	var decoded try.Try[int]
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Err() != errNotFound {
+		t.Fatalf("expected the sentinel, got %#v", decoded.Err())
+	}
+}
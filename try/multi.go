@@ -0,0 +1,121 @@
+//
+//  multi.go
+//  try
+//
+//  Created by d-exclaimation on 8:05 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package try
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Cause is an error with the source that produced it, e.g. a child task or a stream element
+type Cause struct {
+	// Source describes where the error came from
+	Source string
+
+	// Err is the error produced
+	Err error
+}
+
+// Error return the error message prefixed with the source
+func (c Cause) Error() string {
+	if c.Source == "" {
+		return c.Err.Error()
+	}
+	return c.Source + ": " + c.Err.Error()
+}
+
+// Unwrap return the error produced
+func (c Cause) Unwrap() error {
+	return c.Err
+}
+
+// MultiError is an error aggregating multiple errors with their sources.
+//
+//  var errs try.MultiError
+//  errs.Append("element 0", err0)
+//  errs.Append("element 1", err1)
+//  return errs.ErrorOrNil()
+//
+// It works with errors.Is and errors.As by matching any of its errors, and exposes Unwrap() []error like errors.Join.
+type MultiError struct {
+	// Causes are the errors in the order they were appended
+	Causes []Cause
+}
+
+// Append adds the error with its source, ignored if the error is nil
+func (m *MultiError) Append(source string, err error) {
+	if err == nil {
+		return
+	}
+	m.Causes = append(m.Causes, Cause{Source: source, Err: err})
+}
+
+// Len return the number of errors
+func (m *MultiError) Len() int {
+	return len(m.Causes)
+}
+
+// ErrorOrNil return the MultiError if there is any error, otherwise nil
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.Causes) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error return every error on its own line with its source
+//
+//  2 errors occurred:
+//      * task 0: connection refused
+//      * task 2: timeout
+func (m *MultiError) Error() string {
+	switch len(m.Causes) {
+	case 0:
+		return "no errors occurred"
+	case 1:
+		return m.Causes[0].Error()
+	}
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%d errors occurred:", len(m.Causes))
+	for _, cause := range m.Causes {
+		b.WriteString("\n\t* ")
+		b.WriteString(strings.ReplaceAll(cause.Error(), "\n", "\n\t  "))
+	}
+	return b.String()
+}
+
+// Unwrap return all errors with their sources, the same shape as errors.Join
+func (m *MultiError) Unwrap() []error {
+	res := make([]error, 0, len(m.Causes))
+	for _, cause := range m.Causes {
+		res = append(res, cause)
+	}
+	return res
+}
+
+// Is reports whether any of the errors matches the target, for errors.Is
+func (m *MultiError) Is(target error) bool {
+	for _, cause := range m.Causes {
+		if errors.Is(cause.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error that matches the target and sets the target to it, for errors.As
+func (m *MultiError) As(target any) bool {
+	for _, cause := range m.Causes {
+		if errors.As(cause, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+//go:build go1.20
+
+package try_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+func TestMultiErrorJoin(t *testing.T) {
+	var errs try.MultiError
+	errs.Append("a", errNotFound)
+	errs.Append("b", &validationError{Field: "x"})
+	other := errors.New("other")
+
+	// MultiError inside errors.Join, and errors.Join inside MultiError
+	joined := errors.Join(errs.ErrorOrNil(), other)
+	var ve *validationError
+	if !errors.Is(joined, errNotFound) || !errors.Is(joined, other) || !errors.As(joined, &ve) {
+		t.Fatalf("expected errors.Join to reach every cause, got %v", joined)
+	}
+
+	var wrapped try.MultiError
+	wrapped.Append("c", errors.Join(other, errNotFound))
+	if !errors.Is(wrapped.ErrorOrNil(), errNotFound) || !errors.Is(wrapped.ErrorOrNil(), other) {
+		t.Fatal("expected MultiError to reach the errors of errors.Join")
+	}
+
+	// The same shape as errors.Join, so either can be walked the same way
+	var multi interface{ Unwrap() []error }
+	if !errors.As(joined, &multi) || len(multi.Unwrap()) != 2 {
+		t.Fatalf("expected Unwrap() []error, got %v", multi)
+	}
+}
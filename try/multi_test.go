@@ -0,0 +1,131 @@
+package try_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/task"
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+func TestMultiErrorFormat(t *testing.T) {
+	var errs try.MultiError
+	if errs.ErrorOrNil() != nil {
+		t.Fatal("expected nil without errors")
+	}
+	if msg := errs.Error(); msg != "no errors occurred" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+
+	errs.Append("task 0", nil)
+	errs.Append("task 0", errors.New("connection refused"))
+	if errs.Len() != 1 {
+		t.Fatalf("expected nil errors to be ignored, got %d", errs.Len())
+	}
+	if msg := errs.Error(); msg != "task 0: connection refused" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+
+	var nested try.MultiError
+	nested.Append("attempt 1", errors.New("timeout"))
+	nested.Append("attempt 2", errors.New("reset"))
+	errs.Append("task 2", &nested)
+	errs.Append("", errors.New("unknown"))
+	expected := "3 errors occurred:" +
+		"\n\t* task 0: connection refused" +
+		"\n\t* task 2: 2 errors occurred:" +
+		"\n\t  \t* attempt 1: timeout" +
+		"\n\t  \t* attempt 2: reset" +
+		"\n\t* unknown"
+	if msg := errs.Error(); msg != expected {
+		t.Fatalf("expected %q, got %q", expected, msg)
+	}
+}
+
+func TestMultiErrorCollect(t *testing.T) {
+	res := try.Collect([]*try.Try[int]{
+		try.Success(1),
+		try.Failure[int](errNotFound),
+		try.Failure[int](&validationError{Field: "name"}),
+	})
+	var multi *try.MultiError
+	if !res.As(&multi) || multi.Len() != 2 {
+		t.Fatalf("expected a MultiError of 2, got %v", res.Err())
+	}
+	if multi.Causes[0].Source != "element 1" || multi.Causes[1].Source != "element 2" {
+		t.Fatalf("unexpected sources %v", multi.Causes)
+	}
+	if !errors.Is(res.Err(), errNotFound) {
+		t.Fatal("expected errors.Is to match any cause")
+	}
+	var ve *validationError
+	if !errors.As(res.Err(), &ve) || ve.Field != "name" {
+		t.Fatalf("expected errors.As to find the cause, got %v", ve)
+	}
+	if errors.Is(res.Err(), errors.New("not found")) {
+		t.Fatal("expected an unrelated error not to match")
+	}
+}
+
+func TestMultiErrorAll(t *testing.T) {
+	fetch := task.New(func() (int, error) { return 0, errNotFound }).Named("fetch")
+	fetch.Run()
+	_, err := task.All(
+		task.Async(func() (int, error) { return 1, nil }),
+		fetch,
+		task.Async(func() (int, error) { return 0, &validationError{Field: "id"} }),
+	).Await()
+
+	var multi *try.MultiError
+	if !errors.As(err, &multi) || multi.Len() != 2 {
+		t.Fatalf("expected a MultiError of 2, got %v", err)
+	}
+	if multi.Causes[0].Source != "fetch" || multi.Causes[1].Source != "task 2" {
+		t.Fatalf("expected sources by name or index, got %v", multi.Causes)
+	}
+	var ve *validationError
+	if !errors.Is(err, errNotFound) || !errors.As(err, &ve) || ve.Field != "id" {
+		t.Fatalf("expected errors.Is and errors.As to match the causes, got %v", err)
+	}
+}
+
+func TestMultiErrorRetry(t *testing.T) {
+	attempts := 0
+	_, err := task.Retry(3, func() (int, error) {
+		attempts++
+		if attempts == 3 {
+			return 0, &validationError{Field: "body"}
+		}
+		return 0, errNotFound
+	}).Await()
+
+	var multi *try.MultiError
+	if !errors.As(err, &multi) || multi.Len() != 3 {
+		t.Fatalf("expected a MultiError of 3, got %v", err)
+	}
+	if multi.Causes[2].Source != "attempt 3" {
+		t.Fatalf("unexpected source %q", multi.Causes[2].Source)
+	}
+	var ve *validationError
+	if !errors.Is(err, errNotFound) || !errors.As(err, &ve) || ve.Field != "body" {
+		t.Fatalf("expected errors.Is and errors.As to match the attempts, got %v", err)
+	}
+
+	if _, err := task.Retry(0, func() (int, error) { return 1, nil }).Await(); !errors.Is(err, task.ErrNoAttempts) {
+		t.Fatalf("expected ErrNoAttempts, got %v", err)
+	}
+}
+
+func TestMultiErrorUnwrap(t *testing.T) {
+	var errs try.MultiError
+	errs.Append("a", errNotFound)
+	errs.Append("b", &validationError{Field: "x"})
+
+	unwrapped := errs.Unwrap()
+	if len(unwrapped) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(unwrapped))
+	}
+	if unwrapped[0].Error() != "a: not found" || !errors.Is(unwrapped[0], errNotFound) {
+		t.Fatalf("expected the cause with its source, got %v", unwrapped[0])
+	}
+}
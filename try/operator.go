@@ -0,0 +1,85 @@
+//
+//  operator.go
+//  try
+//
+//  Created by d-exclaimation on 4:12 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package try
+
+import "fmt"
+
+// Map transformed a successful value of a Try into a new type, keeping the error otherwise
+func Map[T, K any](t *Try[T], transform func(T) K) *Try[K] {
+	if !t.IsSuccess() {
+		return Failure[K](t.error)
+	}
+	return Success(transform(t.value))
+}
+
+// FlatMap transformed a successful value of a Try into a Try with new type, keeping the error otherwise
+func FlatMap[T, K any](t *Try[T], transform func(T) *Try[K]) *Try[K] {
+	if !t.IsSuccess() {
+		return Failure[K](t.error)
+	}
+	return transform(t.value)
+}
+
+// Recover recovers an error into a successful value, keeping the value otherwise
+func Recover[T any](t *Try[T], recovery func(error) T) *Try[T] {
+	if t.IsSuccess() {
+		return t
+	}
+	return Success(recovery(t.error))
+}
+
+// RecoverWith recovers an error into a new Try, keeping the value otherwise
+func RecoverWith[T any](t *Try[T], recovery func(error) *Try[T]) *Try[T] {
+	if t.IsSuccess() {
+		return t
+	}
+	return recovery(t.error)
+}
+
+// Sequence turns a slice of Try into a Try of all values, failing with the first error
+func Sequence[T any](tries []*Try[T]) *Try[[]T] {
+	res := make([]T, 0, len(tries))
+	for _, t := range tries {
+		if !t.IsSuccess() {
+			return Failure[[]T](t.error)
+		}
+		res = append(res, t.value)
+	}
+	return Success(res)
+}
+
+// Traverse transforms each value into a Try and collect all values, failing with the first error
+func Traverse[T, K any](values []T, transform func(T) *Try[K]) *Try[[]K] {
+	res := make([]K, 0, len(values))
+	for _, value := range values {
+		t := transform(value)
+		if !t.IsSuccess() {
+			return Failure[[]K](t.error)
+		}
+		res = append(res, t.value)
+	}
+	return Success(res)
+}
+
+// Collect turns a slice of Try into a Try of all values, failing with a MultiError of every error by element index
+func Collect[T any](tries []*Try[T]) *Try[[]T] {
+	var errs MultiError
+	res := make([]T, 0, len(tries))
+	for i, t := range tries {
+		if !t.IsSuccess() {
+			errs.Append(fmt.Sprintf("element %d", i), t.error)
+			continue
+		}
+		res = append(res, t.value)
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return Failure[[]T](err)
+	}
+	return Success(res)
+}
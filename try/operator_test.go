@@ -0,0 +1,150 @@
+package try_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/d-exclaimation/gocurrent/try"
+)
+
+func TestMap(t *testing.T) {
+	res := try.Map(try.Success(2), strconv.Itoa)
+	if v, err := res.ToOption(); err != nil || v != "2" {
+		t.Fatalf("expected \"2\", got %v, %v", v, err)
+	}
+
+	called := false
+	failed := try.Map(try.Failure[int](errNotFound), func(int) string {
+		called = true
+		return ""
+	})
+	if !failed.Is(errNotFound) || called {
+		t.Fatalf("expected the error to be kept without transforming, got %v", failed.Err())
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	parse := func(s string) *try.Try[int] {
+		return try.New(strconv.Atoi(s))
+	}
+	if v, err := try.FlatMap(try.Success("4"), parse).ToOption(); err != nil || v != 4 {
+		t.Fatalf("expected 4, got %v, %v", v, err)
+	}
+	if res := try.FlatMap(try.Success("x"), parse); res.IsSuccess() {
+		t.Fatal("expected the transform's failure")
+	}
+	if res := try.FlatMap(try.Failure[string](errNotFound), parse); !res.Is(errNotFound) {
+		t.Fatalf("expected the source's error, got %v", res.Err())
+	}
+}
+
+func TestRecover(t *testing.T) {
+	res := try.Recover(try.Failure[int](errNotFound), func(err error) int {
+		if errors.Is(err, errNotFound) {
+			return -1
+		}
+		return 0
+	})
+	if v, err := res.ToOption(); err != nil || v != -1 {
+		t.Fatalf("expected -1, got %v, %v", v, err)
+	}
+
+	called := false
+	kept := try.Recover(try.Success(1), func(error) int {
+		called = true
+		return 0
+	})
+	if kept.MustGet() != 1 || called {
+		t.Fatal("expected the value to be kept without recovering")
+	}
+}
+
+func TestRecoverWith(t *testing.T) {
+	other := errors.New("other")
+	if v := try.RecoverWith(try.Failure[int](errNotFound), func(error) *try.Try[int] {
+		return try.Success(-1)
+	}).MustGet(); v != -1 {
+		t.Fatalf("expected -1, got %d", v)
+	}
+	if res := try.RecoverWith(try.Failure[int](errNotFound), func(error) *try.Try[int] {
+		return try.Failure[int](other)
+	}); !res.Is(other) || res.Is(errNotFound) {
+		t.Fatalf("expected the recovery's error, got %v", res.Err())
+	}
+	if v := try.RecoverWith(try.Success(1), func(error) *try.Try[int] {
+		return try.Success(0)
+	}).MustGet(); v != 1 {
+		t.Fatalf("expected the value to be kept, got %d", v)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	res := try.Sequence([]*try.Try[int]{try.Success(1), try.Success(2)})
+	if v := res.MustGet(); len(v) != 2 || v[0] != 1 || v[1] != 2 {
+		t.Fatalf("expected [1 2], got %v", v)
+	}
+	if v := try.Sequence([]*try.Try[int]{}).MustGet(); len(v) != 0 {
+		t.Fatalf("expected empty, got %v", v)
+	}
+
+	other := errors.New("other")
+	failed := try.Sequence([]*try.Try[int]{try.Success(1), try.Failure[int](errNotFound), try.Failure[int](other)})
+	if !failed.Is(errNotFound) || failed.Is(other) {
+		t.Fatalf("expected the first error only, got %v", failed.Err())
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	parse := func(s string) *try.Try[int] {
+		return try.New(strconv.Atoi(s))
+	}
+	if v := try.Traverse([]string{"1", "2"}, parse).MustGet(); len(v) != 2 || v[0] != 1 || v[1] != 2 {
+		t.Fatalf("expected [1 2], got %v", v)
+	}
+
+	var seen []string
+	failed := try.Traverse([]string{"1", "x", "y"}, func(s string) *try.Try[int] {
+		seen = append(seen, s)
+		return parse(s)
+	})
+	var ne *strconv.NumError
+	if !failed.As(&ne) || ne.Num != "x" {
+		t.Fatalf("expected the first error, got %v", failed.Err())
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected to stop at the first error, transformed %v", seen)
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	if v := try.Success(1).MustGet(); v != 1 {
+		t.Fatalf("expected 1, got %d", v)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, errNotFound) {
+			t.Fatalf("expected to panic with the error, got %v", err)
+		}
+	}()
+	try.Failure[int](errNotFound).MustGet()
+	t.Fatal("expected MustGet to panic")
+}
+
+func TestIsAs(t *testing.T) {
+	var ve *validationError
+	if try.Success(1).Is(errNotFound) || try.Success(1).As(&ve) {
+		t.Fatal("expected a success to match nothing")
+	}
+
+	res := try.Failure[int](&validationError{Field: "name"})
+	if !res.As(&ve) || ve.Field != "name" {
+		t.Fatalf("expected As to find the error, got %v", ve)
+	}
+	if res.Is(errNotFound) {
+		t.Fatal("expected an unrelated error not to match")
+	}
+	if !try.Failure[int](errNotFound).Is(errNotFound) {
+		t.Fatal("expected Is to match the error")
+	}
+}
@@ -0,0 +1,179 @@
+//
+//  registry.go
+//  try
+//
+//  Created by d-exclaimation on 7:20 PM.
+//  Copyright © 2021 d-exclaimation. All rights reserved.
+//
+
+package try
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// RemoteError is an error decoded from an encoded Try whose type was not registered,
+// or that wrapped a registered error
+type RemoteError struct {
+	// Type is the name of the original error type
+	Type string
+
+	// Message is the original error message
+	Message string
+
+	// cause is the registered error it wrapped if any
+	cause error
+}
+
+// Error return the original error message
+func (r *RemoteError) Error() string {
+	return r.Message
+}
+
+// Unwrap return the registered error it wrapped, nil if none
+func (r *RemoteError) Unwrap() error {
+	return r.cause
+}
+
+// registration is how a registered error is encoded and decoded
+type registration struct {
+	// name is the type name in the encoding
+	name string
+
+	// sentinel is the exact error value for sentinel registration
+	sentinel error
+
+	// kind is the error type for typed registration
+	kind reflect.Type
+}
+
+var (
+	// registryMutex guards the registries
+	registryMutex sync.RWMutex
+
+	// byName are the registrations by encoded type name
+	byName = make(map[string]registration)
+
+	// byType are the typed registrations by error type
+	byType = make(map[reflect.Type]registration)
+
+	// sentinels are the sentinel registrations in order
+	sentinels []registration
+)
+
+// RegisterError registers an error type to be reconstructed when decoding, where the error is encoded as JSON data
+// alongside its message.
+//
+//  try.RegisterError[*ValidationError]("validation")
+func RegisterError[E error](name string) {
+	kind := reflect.TypeOf((*E)(nil)).Elem()
+	reg := registration{name: name, kind: kind}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	byName[name] = reg
+	byType[kind] = reg
+}
+
+// RegisterSentinel registers an error value to be decoded back into the same value, matched by equality.
+//
+//  try.RegisterSentinel("not_found", ErrNotFound)
+func RegisterSentinel(name string, err error) {
+	reg := registration{name: name, sentinel: err}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	byName[name] = reg
+	sentinels = append(sentinels, reg)
+}
+
+// encodedError is the encoding of an error
+type encodedError struct {
+	Message string          `json:"message"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// encodeError describes the error with its registered name, or its Go type if not registered
+func encodeError(err error) (encodedError, error) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	res := encodedError{Message: err.Error(), Type: fmt.Sprintf("%T", err)}
+	if remote, ok := err.(*RemoteError); ok {
+		res.Type = remote.Type
+		return res, nil
+	}
+	for _, reg := range sentinels {
+		if errors.Is(err, reg.sentinel) {
+			res.Type = reg.name
+			return res, nil
+		}
+	}
+	for inner := err; inner != nil; inner = errors.Unwrap(inner) {
+		reg, ok := byType[reflect.TypeOf(inner)]
+		if !ok {
+			continue
+		}
+		data, e := json.Marshal(inner)
+		if e != nil {
+			return res, e
+		}
+		res.Type = reg.name
+		res.Data = data
+		break
+	}
+	return res, nil
+}
+
+// decodeError reconstructs the registered error, wrapped in a RemoteError with the original message if it was
+// wrapped, or a RemoteError if not registered
+func decodeError(enc encodedError) (error, error) {
+	registryMutex.RLock()
+	reg, ok := byName[enc.Type]
+	registryMutex.RUnlock()
+
+	if !ok {
+		return &RemoteError{Type: enc.Type, Message: enc.Message}, nil
+	}
+	res, err := reg.decode(enc)
+	if err != nil || res.Error() == enc.Message {
+		return res, err
+	}
+	return &RemoteError{Type: enc.Type, Message: enc.Message, cause: res}, nil
+}
+
+// decode reconstructs the registered error itself
+func (reg registration) decode(enc encodedError) (error, error) {
+	if reg.sentinel != nil {
+		return reg.sentinel, nil
+	}
+
+	// Decode into a new value of the registered type, allocating the pointee for pointer types
+	var target reflect.Value
+	if reg.kind.Kind() == reflect.Ptr {
+		target = reflect.New(reg.kind.Elem())
+		if len(enc.Data) > 0 {
+			if err := json.Unmarshal(enc.Data, target.Interface()); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		ptr := reflect.New(reg.kind)
+		if len(enc.Data) > 0 {
+			if err := json.Unmarshal(enc.Data, ptr.Interface()); err != nil {
+				return nil, err
+			}
+		}
+		target = ptr.Elem()
+	}
+	res, ok := target.Interface().(error)
+	if !ok {
+		return &RemoteError{Type: enc.Type, Message: enc.Message}, nil
+	}
+	return res, nil
+}
@@ -8,6 +8,8 @@
 
 package try
 
+import "errors"
+
 // Try is a data structure representing a data, error pair
 type Try[T any] struct {
 	value T
@@ -20,6 +22,17 @@ func From[T any](op func() (T, error)) *Try[T] {
 	return &Try[T]{value, err}
 }
 
+// Success instantiate a new successful Try
+func Success[T any](value T) *Try[T] {
+	return &Try[T]{value, nil}
+}
+
+// Failure instantiate a new unsuccessful Try
+func Failure[T any](err error) *Try[T] {
+	var zero T
+	return &Try[T]{zero, err}
+}
+
 // New instantiate a new Try from a tuple
 func New[T any](value T, err error) *Try[T] {
 	return &Try[T]{value, err}
@@ -56,3 +69,34 @@ func (try *Try[T]) OrElse(fallback T) T {
 	}
 	return fallback
 }
+
+// OrElseGet return the successful value if succeeded, or the value computed from the error
+func (try *Try[T]) OrElseGet(fallback func(error) T) T {
+	if try.IsSuccess() {
+		return try.Get()
+	}
+	return fallback(try.error)
+}
+
+// Err return the error if failed, otherwise nil
+func (try *Try[T]) Err() error {
+	return try.error
+}
+
+// MustGet return the successful value, or panic with the error if failed
+func (try *Try[T]) MustGet() T {
+	if !try.IsSuccess() {
+		panic(try.error)
+	}
+	return try.value
+}
+
+// Is reports whether the error matches the target using errors.Is, false if succeeded
+func (try *Try[T]) Is(target error) bool {
+	return !try.IsSuccess() && errors.Is(try.error, target)
+}
+
+// As finds the first error in the chain that matches the target using errors.As, false if succeeded
+func (try *Try[T]) As(target interface{}) bool {
+	return !try.IsSuccess() && errors.As(try.error, target)
+}
@@ -17,3 +17,9 @@ type Function func() (Any, error)
 // Signal is a data structure for simulating notification with little memory allocation
 type Signal struct{}
 
+// Number is a constraint for any built-in numeric types
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}